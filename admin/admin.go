@@ -0,0 +1,239 @@
+// Package admin provides an HTTP API to inspect and manage jobs of a cron
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/anticrew/gocron"
+)
+
+// Job describes a registered job
+type Job struct {
+	Name    string    `json:"name"`
+	Spec    string    `json:"spec"`
+	Group   string    `json:"group,omitempty"`
+	Next    time.Time `json:"next,omitzero"`
+	Prev    time.Time `json:"prev,omitzero"`
+	Paused  bool      `json:"paused"`
+	Running int       `json:"running"`
+	// LastError is the error of the last finished run
+	LastError string `json:"lastError,omitempty"`
+	// LastDuration is the duration of the last finished run, e.g. 1.5s
+	LastDuration string `json:"lastDuration,omitempty"`
+}
+
+// Status describes the cron
+type Status struct {
+	State  string `json:"state"`
+	Leader bool   `json:"leader"`
+}
+
+// Schedule is the body of reschedule requests
+type Schedule struct {
+	Spec string `json:"spec"`
+}
+
+// Error is the body of error responses
+type Error struct {
+	Error string `json:"error"`
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// Handler serves JSON endpoints managing the cron:
+//   - GET /status returns Status of the cron;
+//   - POST /pause and POST /resume pause and resume the cron;
+//   - GET /jobs returns Job list ordered by the next run time;
+//   - GET /runs returns Run list of all jobs from the most recent one, see WithHistory;
+//   - GET /jobs/{name}/runs returns Run list of the job;
+//   - POST /jobs/{name}/trigger runs the job in background and responds with 202 Accepted;
+//   - POST /jobs/{name}/pause and POST /jobs/{name}/resume pause and resume the job;
+//   - PUT /jobs/{name}/schedule reschedules the job with Schedule body.
+//
+// Endpoints addressing jobs by name affect all jobs with the name and respond with 404 Not Found if there are none.
+// Mount it with http.StripPrefix to serve under a prefix
+type Handler struct {
+	cron    gocron.Cron
+	history *History
+	mux     *http.ServeMux
+}
+
+// NewHandler creates a handler of the cron
+func NewHandler(cron gocron.Cron) *Handler {
+	h := &Handler{
+		cron: cron,
+		mux:  http.NewServeMux(),
+	}
+
+	h.mux.HandleFunc("GET /status", h.status)
+	h.mux.HandleFunc("POST /pause", h.pause)
+	h.mux.HandleFunc("POST /resume", h.resume)
+	h.mux.HandleFunc("GET /jobs", h.jobs)
+	h.mux.HandleFunc("GET /runs", h.runs)
+	h.mux.HandleFunc("GET /jobs/{name}/runs", h.runs)
+	h.mux.HandleFunc("POST /jobs/{name}/trigger", h.trigger)
+	h.mux.HandleFunc("POST /jobs/{name}/pause", h.pauseJob)
+	h.mux.HandleFunc("POST /jobs/{name}/resume", h.resumeJob)
+	h.mux.HandleFunc("PUT /jobs/{name}/schedule", h.reschedule)
+
+	return h
+}
+
+// WithHistory sets the history serving runs endpoints; without it they respond with 404 Not Found.
+// The history must receive job events, e.g. via gocron.WithDefaultHandler
+func (h *Handler) WithHistory(history *History) *Handler {
+	h.history = history
+	return h
+}
+
+// ServeHTTP serves the admin API
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) status(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, Status{
+		State:  h.cron.State().String(),
+		Leader: h.cron.Leader(),
+	})
+}
+
+func (h *Handler) pause(w http.ResponseWriter, _ *http.Request) {
+	h.cron.Pause()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) resume(w http.ResponseWriter, _ *http.Request) {
+	h.cron.Resume()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) jobs(w http.ResponseWriter, _ *http.Request) {
+	infos := h.cron.Jobs()
+
+	jobs := make([]Job, 0, len(infos))
+	for _, info := range infos {
+		job := Job{
+			Name:    info.Name,
+			Spec:    info.Spec,
+			Group:   info.Group,
+			Next:    info.Next,
+			Prev:    info.Prev,
+			Paused:  info.Paused,
+			Running: info.Running,
+		}
+
+		if info.LastError != nil {
+			job.LastError = info.LastError.Error()
+		}
+
+		if info.LastDuration > 0 {
+			job.LastDuration = info.LastDuration.String()
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+func (h *Handler) runs(w http.ResponseWriter, r *http.Request) {
+	if h.history == nil {
+		writeError(w, http.StatusNotFound, errors.New("run history is disabled"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.history.Runs(r.PathValue("name")))
+}
+
+// trigger runs the jobs detached from the request, so they are neither bound to the request timeout
+// nor cancelled once the client disconnects. The runs are still awaited by Shutdown
+func (h *Handler) trigger(w http.ResponseWriter, r *http.Request) {
+	jobs, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := context.WithoutCancel(r.Context())
+	for _, j := range jobs {
+		go func() {
+			_ = j.RunNow(ctx)
+		}()
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) pauseJob(w http.ResponseWriter, r *http.Request) {
+	jobs, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+
+	for _, j := range jobs {
+		j.Pause()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) resumeJob(w http.ResponseWriter, r *http.Request) {
+	jobs, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+
+	for _, j := range jobs {
+		j.Resume()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) reschedule(w http.ResponseWriter, r *http.Request) {
+	var schedule Schedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jobs, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+
+	for _, j := range jobs {
+		if err := j.Reschedule(schedule.Spec); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// find returns jobs with the name from the request path or responds with 404 Not Found if there are none
+func (h *Handler) find(w http.ResponseWriter, r *http.Request) ([]gocron.Job, bool) {
+	jobs := h.cron.FindByName(r.PathValue("name"))
+	if len(jobs) == 0 {
+		writeError(w, http.StatusNotFound, gocron.ErrJobNotFound)
+		return nil, false
+	}
+
+	return jobs, true
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, Error{Error: err.Error()})
+}
@@ -0,0 +1,179 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/anticrew/gocron/gocrontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve performs the request and returns the response code and body
+func serve(h http.Handler, method, target, body string) (int, string) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+	return rec.Code, rec.Body.String()
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = t.Context()
+		history  = NewHistory(0)
+		recorder = gocrontest.NewRecorder()
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithDefaultHandler(gocron.MultiHandler(history, recorder)))
+	c.MustAdd("@yearly", func(context.Context) error { return assert.AnError }).WithName("export")
+
+	h := NewHandler(c).WithHistory(history)
+
+	code, body := serve(h, http.MethodGet, "/status", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"state": "new", "leader": false}`, body)
+
+	code, _ = serve(h, http.MethodPost, "/jobs/export/trigger", "")
+	require.Equal(t, http.StatusAccepted, code)
+
+	_, ok := recorder.WaitFor(gocron.StageFinish, "export", 5*time.Second)
+	require.True(t, ok)
+
+	code, body = serve(h, http.MethodGet, "/jobs/export/runs", "")
+	require.Equal(t, http.StatusOK, code)
+
+	var runs []Run
+	require.NoError(t, json.Unmarshal([]byte(body), &runs))
+	require.Len(t, runs, 1)
+	assert.Equal(t, "manual", runs[0].Trigger)
+	assert.Equal(t, RunFailed, runs[0].Status)
+	assert.Equal(t, assert.AnError.Error(), runs[0].Error)
+
+	code, _ = serve(h, http.MethodPost, "/jobs/export/pause", "")
+	assert.Equal(t, http.StatusNoContent, code)
+
+	code, _ = serve(h, http.MethodPut, "/jobs/export/schedule", `{"spec": "@daily"}`)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	code, body = serve(h, http.MethodGet, "/jobs", "")
+	require.Equal(t, http.StatusOK, code)
+
+	var jobs []Job
+	require.NoError(t, json.Unmarshal([]byte(body), &jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "export", jobs[0].Name)
+	assert.Equal(t, "@daily", jobs[0].Spec)
+	assert.True(t, jobs[0].Paused)
+	assert.Equal(t, assert.AnError.Error(), jobs[0].LastError)
+
+	code, _ = serve(h, http.MethodPost, "/jobs/export/resume", "")
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.False(t, c.Jobs()[0].Paused)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	c := gocron.NewCron(t.Context())
+	c.MustAdd("@yearly", func(context.Context) error { return nil }).WithName("export")
+
+	tests := []struct {
+		name         string
+		handler      http.Handler
+		method       string
+		target       string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "unknown job",
+			handler:      NewHandler(c),
+			method:       http.MethodPost,
+			target:       "/jobs/unknown/trigger",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid spec",
+			handler:      NewHandler(c),
+			method:       http.MethodPut,
+			target:       "/jobs/export/schedule",
+			body:         `{"spec": "bad spec"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid body",
+			handler:      NewHandler(c),
+			method:       http.MethodPut,
+			target:       "/jobs/export/schedule",
+			body:         `spec`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "history disabled",
+			handler:      NewHandler(c),
+			method:       http.MethodGet,
+			target:       "/runs",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "wrong method",
+			handler:      NewHandler(c),
+			method:       http.MethodGet,
+			target:       "/jobs/export/trigger",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, _ := serve(tc.handler, tc.method, tc.target, tc.body)
+			assert.Equal(t, tc.expectedCode, code)
+		})
+	}
+}
+
+func TestHandler_Pause(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = t.Context()
+		clock    = gocrontest.NewFakeClock(time.Now())
+		recorder = gocrontest.NewRecorder()
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithDefaultHandler(recorder))
+	c.MustAdd("@every 1s", func(context.Context) error { return nil }).WithName("export")
+	h := NewHandler(c)
+
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		_ = c.Shutdown(ctx)
+	})
+
+	code, _ := serve(h, http.MethodPost, "/pause", "")
+	require.Equal(t, http.StatusNoContent, code)
+
+	clock.Advance(time.Second)
+
+	event, ok := recorder.WaitFor(gocron.StageSkip, "export", 0)
+	require.True(t, ok)
+	require.ErrorIs(t, event.Error, gocron.ErrCronPaused)
+
+	code, _ = serve(h, http.MethodPost, "/resume", "")
+	require.Equal(t, http.StatusNoContent, code)
+
+	clock.Advance(time.Second)
+
+	_, ok = recorder.WaitFor(gocron.StageExec, "export", 0)
+	assert.True(t, ok)
+}
@@ -0,0 +1,190 @@
+package admin
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/anticrew/gocron"
+)
+
+// DefaultHistorySize is the number of runs kept by History unless another size is specified
+const DefaultHistorySize = 100
+
+// RunStatus is the outcome of a recorded run
+type RunStatus string
+
+const (
+	// RunRunning indicates the run holds the lock and hasn't finished yet
+	RunRunning RunStatus = "running"
+	// RunSucceeded indicates the run finished without errors
+	RunSucceeded RunStatus = "succeeded"
+	// RunFailed indicates the run failed to lock, execute or unlock
+	RunFailed RunStatus = "failed"
+	// RunSkipped indicates the run was skipped, e.g. because the job was paused or locked by another run
+	RunSkipped RunStatus = "skipped"
+)
+
+// Run describes a recorded job run
+type Run struct {
+	ID      string    `json:"id"`
+	Job     string    `json:"job"`
+	Trigger string    `json:"trigger"`
+	Status  RunStatus `json:"status"`
+	// Error is the run error or the skip reason
+	Error string `json:"error,omitempty"`
+	// Attempts is the number of command executions
+	Attempts    int       `json:"attempts,omitempty"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+	StartedAt   time.Time `json:"startedAt"`
+	FinishedAt  time.Time `json:"finishedAt,omitzero"`
+}
+
+var _ gocron.Handler = (*History)(nil)
+
+// History is a gocron.Handler keeping the most recent runs of all jobs for the admin API
+type History struct {
+	size int
+	now  func() time.Time
+
+	mu   sync.Mutex
+	runs []Run
+	// errs holds errors of unfinished runs by run IDs
+	errs map[string]runErrors
+}
+
+// runErrors are errors of an unfinished run
+type runErrors struct {
+	// exec is the error of the last command execution attempt
+	exec    error
+	refresh error
+}
+
+// NewHistory creates a history keeping size most recent runs; non-positive size means DefaultHistorySize
+func NewHistory(size int) *History {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+
+	return &History{
+		size: size,
+		now:  time.Now,
+		errs: make(map[string]runErrors),
+	}
+}
+
+// Handle records the run of the event
+func (h *History) Handle(event gocron.JobEvent) {
+	if event.RunID == "" {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch event.Stage {
+	case gocron.StageStart:
+		status := RunRunning
+		if event.Error != nil {
+			status = RunFailed
+		}
+
+		h.add(event, status, event.Error)
+
+	case gocron.StageSkip:
+		h.add(event, RunSkipped, event.Error)
+
+	case gocron.StageLimit:
+		h.add(event, RunFailed, event.Error)
+
+	case gocron.StageExec, gocron.StagePanic:
+		if run := h.find(event.RunID); run != nil {
+			run.Attempts = event.Attempt
+
+			errs := h.errs[run.ID]
+			errs.exec = event.Error
+			h.errs[run.ID] = errs
+		}
+
+	case gocron.StageRefresh:
+		if run := h.find(event.RunID); run != nil && event.Error != nil {
+			errs := h.errs[run.ID]
+			errs.refresh = event.Error
+			h.errs[run.ID] = errs
+		}
+
+	case gocron.StageFinish:
+		if run := h.find(event.RunID); run != nil {
+			errs := h.errs[run.ID]
+			h.finish(run, errors.Join(errs.exec, errs.refresh, event.Error))
+		}
+
+	case gocron.StageRemove, gocron.StageQueue:
+	}
+}
+
+// Runs returns recorded runs of the job from the most recent one; empty name returns runs of all jobs
+func (h *History) Runs(name string) []Run {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	runs := make([]Run, 0, len(h.runs))
+	for _, run := range slices.Backward(h.runs) {
+		if name == "" || run.Job == name {
+			runs = append(runs, run)
+		}
+	}
+
+	return runs
+}
+
+// add records a new run, dropping the oldest one if the history is full. Runs with status other than
+// RunRunning are finished at once
+func (h *History) add(event gocron.JobEvent, status RunStatus, err error) {
+	if len(h.runs) == h.size {
+		delete(h.errs, h.runs[0].ID)
+		h.runs = slices.Delete(h.runs, 0, 1)
+	}
+
+	h.runs = append(h.runs, Run{
+		ID:          event.RunID,
+		Job:         event.JobName,
+		Trigger:     event.Trigger.String(),
+		Status:      status,
+		ScheduledAt: event.ScheduledAt,
+		StartedAt:   event.StartedAt,
+	})
+
+	if status != RunRunning {
+		h.finish(&h.runs[len(h.runs)-1], err)
+	}
+}
+
+// find returns the running run with the given ID; nil if it's not recorded
+func (h *History) find(id string) *Run {
+	for i := len(h.runs) - 1; i >= 0; i-- {
+		if h.runs[i].ID == id && h.runs[i].Status == RunRunning {
+			return &h.runs[i]
+		}
+	}
+
+	return nil
+}
+
+func (h *History) finish(run *Run, err error) {
+	delete(h.errs, run.ID)
+
+	run.FinishedAt = h.now()
+
+	if run.Status == RunRunning {
+		run.Status = RunSucceeded
+		if err != nil {
+			run.Status = RunFailed
+		}
+	}
+
+	if err != nil {
+		run.Error = err.Error()
+	}
+}
@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	var (
+		started  = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		finished = started.Add(time.Minute)
+		history  = NewHistory(3)
+	)
+
+	history.now = func() time.Time { return finished }
+
+	run := func(id string) gocron.JobEvent {
+		return gocron.JobEvent{
+			JobName:     "export",
+			RunID:       id,
+			ScheduledAt: started,
+			StartedAt:   started,
+			Trigger:     gocron.TriggerSchedule,
+		}
+	}
+
+	event := func(id string, stage gocron.Stage, attempt int, err error) {
+		e := run(id)
+		e.Stage, e.Attempt, e.Error = stage, attempt, err
+		history.Handle(e)
+	}
+
+	event("succeeded", gocron.StageStart, 0, nil)
+	event("succeeded", gocron.StageExec, 1, assert.AnError)
+	event("succeeded", gocron.StageExec, 2, nil)
+	event("succeeded", gocron.StageFinish, 0, nil)
+
+	event("failed", gocron.StageStart, 0, nil)
+	event("failed", gocron.StageRefresh, 0, gocron.ErrLockLost)
+	event("failed", gocron.StagePanic, 1, assert.AnError)
+	event("failed", gocron.StageFinish, 0, nil)
+
+	event("skipped", gocron.StageSkip, 0, gocron.ErrJobPaused)
+	event("running", gocron.StageStart, 0, nil)
+
+	history.Handle(gocron.JobEvent{JobName: "export", Stage: gocron.StageRemove})
+
+	runs := history.Runs("export")
+	require.Len(t, runs, 3, "the oldest run is dropped")
+
+	assert.Equal(t, Run{
+		ID:          "running",
+		Job:         "export",
+		Trigger:     "schedule",
+		Status:      RunRunning,
+		ScheduledAt: started,
+		StartedAt:   started,
+	}, runs[0])
+
+	assert.Equal(t, Run{
+		ID:          "skipped",
+		Job:         "export",
+		Trigger:     "schedule",
+		Status:      RunSkipped,
+		Error:       gocron.ErrJobPaused.Error(),
+		ScheduledAt: started,
+		StartedAt:   started,
+		FinishedAt:  finished,
+	}, runs[1])
+
+	assert.Equal(t, Run{
+		ID:          "failed",
+		Job:         "export",
+		Trigger:     "schedule",
+		Status:      RunFailed,
+		Error:       assert.AnError.Error() + "\n" + gocron.ErrLockLost.Error(),
+		Attempts:    1,
+		ScheduledAt: started,
+		StartedAt:   started,
+		FinishedAt:  finished,
+	}, runs[2])
+
+	event("running", gocron.StageExec, 1, nil)
+	event("running", gocron.StageFinish, 0, nil)
+
+	runs = history.Runs("")
+	require.Len(t, runs, 3)
+	assert.Equal(t, RunSucceeded, runs[0].Status)
+	assert.Equal(t, 1, runs[0].Attempts)
+	assert.Equal(t, finished, runs[0].FinishedAt)
+
+	assert.Empty(t, history.Runs("other"))
+}
@@ -0,0 +1,37 @@
+package gocron
+
+import "context"
+
+type runInfoKey struct{}
+
+// WithRunInfo returns a copy of ctx holding the run info.
+// Jobs put RunInfo into the contexts passed to Lock, Cmd and handlers; use it to build such contexts in tests
+func WithRunInfo(ctx context.Context, info RunInfo) context.Context {
+	return context.WithValue(ctx, runInfoKey{}, info)
+}
+
+// RunInfoFromContext returns the run info stored in ctx by the job running the Cmd
+func RunInfoFromContext(ctx context.Context) (RunInfo, bool) {
+	info, ok := ctx.Value(runInfoKey{}).(RunInfo)
+	return info, ok
+}
+
+// LockName returns the name a lock guards: name if it's set, otherwise the job name of the run in ctx.
+// It returns ErrNoLockName if both are empty. Lock implementations use it to key locks by job names
+func LockName(ctx context.Context, name string) (string, error) {
+	if name != "" {
+		return name, nil
+	}
+
+	if info, _ := RunInfoFromContext(ctx); info.JobName != "" {
+		return info.JobName, nil
+	}
+
+	return "", ErrNoLockName
+}
+
+// runInfo returns the run info stored in ctx or zero value
+func runInfo(ctx context.Context) RunInfo {
+	info, _ := RunInfoFromContext(ctx)
+	return info
+}
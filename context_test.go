@@ -0,0 +1,110 @@
+package gocron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunInfoFromContext(t *testing.T) {
+	t.Parallel()
+
+	_, ok := RunInfoFromContext(t.Context())
+	assert.False(t, ok)
+	assert.Zero(t, runInfo(t.Context()))
+
+	expected := RunInfo{
+		JobSpec:     "@daily",
+		JobName:     "export",
+		RunID:       "run",
+		ScheduledAt: time.Now(),
+		StartedAt:   time.Now(),
+		Trigger:     TriggerManual,
+	}
+
+	info, ok := RunInfoFromContext(WithRunInfo(t.Context(), expected))
+	assert.True(t, ok)
+	assert.Equal(t, expected, info)
+}
+
+func TestLockName(t *testing.T) {
+	t.Parallel()
+
+	ctx := WithRunInfo(t.Context(), RunInfo{JobName: "export"})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		lockName    string
+		expected    string
+		expectedErr error
+	}{
+		{name: "lock name", ctx: ctx, lockName: "lock", expected: "lock"},
+		{name: "job name", ctx: ctx, expected: "export"},
+		{name: "no name", ctx: t.Context(), expectedErr: ErrNoLockName},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, err := LockName(tc.ctx, tc.lockName)
+			require.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, name)
+		})
+	}
+}
+
+func TestJob_RunInfo(t *testing.T) {
+	t.Parallel()
+
+	type ctxKey struct{}
+
+	var (
+		cmdInfo, lockInfo RunInfo
+		handlerValues     []any
+		events            []JobEvent
+	)
+
+	j := newJob(t.Context(), "spec", func(ctx context.Context) error {
+		cmdInfo, _ = RunInfoFromContext(ctx)
+		return nil
+	})
+	j.WithName("name").
+		WithLock(lockFunc(func(ctx context.Context) error {
+			lockInfo, _ = RunInfoFromContext(ctx)
+			return nil
+		})).
+		WithHandler(ContextHandlerFunc(func(ctx context.Context, event JobEvent) {
+			handlerValues = append(handlerValues, ctx.Value(ctxKey{}))
+			events = append(events, event)
+		}))
+
+	ctx := context.WithValue(t.Context(), ctxKey{}, "value")
+	assert.NoError(t, j.RunNow(ctx))
+
+	assert.Equal(t, "name", cmdInfo.JobName)
+	assert.Equal(t, "spec", cmdInfo.JobSpec)
+	assert.Equal(t, TriggerManual, cmdInfo.Trigger)
+	assert.Len(t, cmdInfo.RunID, runIDSize)
+	assert.Equal(t, cmdInfo.StartedAt, cmdInfo.ScheduledAt)
+	assert.Equal(t, cmdInfo, lockInfo)
+
+	assert.Equal(t, []any{"value", "value", "value"}, handlerValues)
+	for _, event := range events {
+		assert.Equal(t, cmdInfo.RunID, event.RunID)
+	}
+}
+
+type lockFunc func(ctx context.Context) error
+
+func (f lockFunc) Lock(ctx context.Context) error {
+	return f(ctx)
+}
+
+func (f lockFunc) Unlock(context.Context) error {
+	return nil
+}
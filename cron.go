@@ -1,8 +1,11 @@
 package gocron
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,22 +15,45 @@ import (
 )
 
 type defaults struct {
-	handler Handler
-	timeout time.Duration
+	handler      Handler
+	timeout      time.Duration
+	lockProvider LockProvider
+	tracer       Tracer
+}
+
+type shutdown struct {
+	cancelAfter time.Duration
 }
 
 type cron struct {
-	started atomic.Bool
+	paused atomic.Bool
+	leader atomic.Bool
 
-	baseCtx context.Context
-	cron    *c.Cron
+	baseCtx   context.Context
+	clock     Clock
+	scheduler *scheduler
+
+	mu    sync.Mutex
+	state State
+	stop  chan struct{}
+	jobs  map[c.EntryID]*job
 
 	defaults defaults
-	wg       *sync.WaitGroup
+	shutdown shutdown
+	runs     *runSet
+	limiter  *limiter
+
+	elector      Elector
+	election     chan struct{}
+	stopElection context.CancelFunc
 }
 
 type optionsHolder struct {
 	defaults    defaults
+	shutdown    shutdown
+	limits      limits
+	elector     Elector
+	clock       Clock
 	cronOptions []c.Option
 }
 
@@ -49,12 +75,47 @@ func WithTimeout(t time.Duration) Option {
 	}
 }
 
+// WithDefaultLockProvider sets the provider of locks used by jobs without a lock set by Job.WithLock.
+// The lock is requested by the job name at the first run and again after the job is renamed
+func WithDefaultLockProvider(p LockProvider) Option {
+	return func(o *optionsHolder) {
+		o.defaults.lockProvider = p
+	}
+}
+
+// WithTracer sets the tracer wrapping runs of all jobs and their lock, command and unlock steps in spans
+func WithTracer(t Tracer) Option {
+	return func(o *optionsHolder) {
+		o.defaults.tracer = t
+	}
+}
+
+// WithClock sets the clock used to schedule jobs and to report run start times; the system clock is used by default.
+// Job timeouts and retry delays always use the system clock. Look at gocrontest.FakeClock for tests
+func WithClock(clock Clock) Option {
+	return func(o *optionsHolder) {
+		o.clock = clock
+	}
+}
+
+// WithCancelOnShutdown enables two-phase shutdown. Once the Shutdown context is done,
+// contexts of running jobs are cancelled and Shutdown waits up to wait more for them to return.
+// Jobs that still run after that are listed in the returned ShutdownError
+func WithCancelOnShutdown(wait time.Duration) Option {
+	return func(o *optionsHolder) {
+		o.shutdown.cancelAfter = wait
+	}
+}
+
 func WithSeconds() Option {
 	return func(o *optionsHolder) {
 		o.cronOptions = append(o.cronOptions, c.WithSeconds())
 	}
 }
 
+// WithOptions passes options to robfig's cron, which parses specs and wraps jobs; gocron schedules jobs itself.
+// c.WithSeconds, c.WithParser and c.WithLocation apply. c.WithChain applies too, but wrapped jobs don't get
+// the planned run time, so RunInfo.ScheduledAt of their runs equals StartedAt. c.WithLogger is ignored
 func WithOptions(opts ...c.Option) Option {
 	return func(o *optionsHolder) {
 		o.cronOptions = append(o.cronOptions, opts...)
@@ -68,11 +129,18 @@ func NewCron(ctx context.Context, options ...Option) Cron {
 		option(&opt)
 	}
 
+	clock := internal.WithDefault[Clock](opt.clock, func() Clock { return realClock{} })
+
 	cr := &cron{
-		baseCtx:  internal.WithDefault(ctx, context.Background),
-		cron:     c.New(opt.cronOptions...),
-		defaults: opt.defaults,
-		wg:       &sync.WaitGroup{},
+		baseCtx:   internal.WithDefault(ctx, context.Background),
+		clock:     clock,
+		scheduler: newScheduler(c.New(opt.cronOptions...), clock),
+		jobs:      make(map[c.EntryID]*job),
+		defaults:  opt.defaults,
+		shutdown:  opt.shutdown,
+		runs:      newRunSet(),
+		limiter:   newLimiter(opt.limits),
+		elector:   opt.elector,
 	}
 
 	return cr
@@ -88,12 +156,22 @@ func (c *cron) Add(spec string, cmd Cmd) (Job, error) {
 	j := newJob(c.baseCtx, spec, cmd)
 	j.WithHandler(c.defaults.handler)
 	j.WithTimeout(c.defaults.timeout)
-	j.withWaitGroup(c.wg)
+	j.withRuns(c.runs)
+	j.withOwner(c)
+	j.withLockProvider(c.defaults.lockProvider)
+	j.withTracer(c.defaults.tracer)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	if _, err := c.cron.AddJob(spec, j); err != nil {
+	id, err := c.scheduler.add(spec, j)
+	if err != nil {
 		return nil, fmt.Errorf("cron.AddJob: %w", err)
 	}
 
+	j.entryID = id
+	c.jobs[id] = j
+
 	return j, nil
 }
 
@@ -103,23 +181,279 @@ func (c *cron) MustAdd(spec string, cmd Cmd) Job {
 	return internal.Must(c.Add(spec, cmd))
 }
 
-// Start begins scheduling jobs.
-// It should be called once, next calls without call Shutdown before will be ignored
-func (c *cron) Start() {
-	if c.started.Swap(true) {
-		return
+// Remove unschedules the job. Running invocations are not interrupted and
+// are still awaited by Shutdown
+func (c *cron) Remove(j Job) error {
+	jb, ok := j.(*job)
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	return c.remove(jb)
+}
+
+// RemoveByName unschedules all jobs with the given name.
+// Running invocations are not interrupted and are still awaited by Shutdown
+func (c *cron) RemoveByName(name string) error {
+	c.mu.Lock()
+
+	var removed []*job
+	for id, j := range c.jobs {
+		if j.getName() != name {
+			continue
+		}
+
+		c.scheduler.remove(id)
+		delete(c.jobs, id)
+		removed = append(removed, j)
+	}
+
+	c.mu.Unlock()
+
+	if len(removed) == 0 {
+		return ErrJobNotFound
+	}
+
+	for _, j := range removed {
+		j.handle(StageRemove, nil)
+	}
+
+	return nil
+}
+
+// Trigger runs all jobs with the given name immediately, one by one, outside their schedule.
+// It returns the joined errors of the runs
+func (c *cron) Trigger(ctx context.Context, name string) error {
+	jobs := c.byName(name)
+	if len(jobs) == 0 {
+		return ErrJobNotFound
+	}
+
+	errs := make([]error, 0, len(jobs))
+	for _, j := range jobs {
+		errs = append(errs, j.RunNow(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// FindByName returns all registered jobs with the given name; nil if there are none
+func (c *cron) FindByName(name string) []Job {
+	found := c.byName(name)
+
+	var jobs []Job
+	for _, j := range found {
+		jobs = append(jobs, j)
+	}
+
+	return jobs
+}
+
+// byName returns registered jobs with the given name ordered by entry IDs
+func (c *cron) byName(name string) []*job {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var jobs []*job
+	for _, j := range c.jobs {
+		if j.getName() == name {
+			jobs = append(jobs, j)
+		}
+	}
+
+	slices.SortFunc(jobs, func(a, b *job) int {
+		return cmp.Compare(a.entryID, b.entryID)
+	})
+
+	return jobs
+}
+
+// Jobs returns a snapshot of all registered jobs ordered by the next run time
+func (c *cron) Jobs() []JobInfo {
+	ids := c.scheduler.order()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	infos := make([]JobInfo, 0, len(ids))
+	for _, id := range ids {
+		j, ok := c.jobs[id]
+		if !ok {
+			continue
+		}
+
+		info := j.info()
+		info.Next, info.Prev = c.scheduler.times(id)
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// reschedule atomically replaces the job entry with a new one scheduled by spec.
+// The new entry is added first, so an invalid spec keeps the old schedule
+func (c *cron) reschedule(j *job, spec string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.jobs[j.entryID] != j {
+		return ErrJobNotFound
 	}
 
-	c.cron.Start()
+	id, err := c.scheduler.add(spec, j)
+	if err != nil {
+		return fmt.Errorf("cron.AddJob: %w", err)
+	}
+
+	c.scheduler.remove(j.entryID)
+	delete(c.jobs, j.entryID)
+
+	j.setSpec(spec)
+	j.entryID = id
+	c.jobs[id] = j
+
+	return nil
+}
+
+func (c *cron) remove(j *job) error {
+	c.mu.Lock()
+
+	if c.jobs[j.entryID] != j {
+		c.mu.Unlock()
+		return ErrJobNotFound
+	}
+
+	c.scheduler.remove(j.entryID)
+	delete(c.jobs, j.entryID)
+
+	c.mu.Unlock()
+
+	j.handle(StageRemove, nil)
+
+	return nil
+}
+
+// Pause skips scheduled runs of all jobs until Resume is called.
+// Jobs stay registered and can still be run manually
+func (c *cron) Pause() {
+	c.paused.Store(true)
+}
+
+// Resume continues scheduled runs paused by Pause.
+// Jobs paused individually stay paused
+func (c *cron) Resume() {
+	c.paused.Store(false)
+}
+
+// State returns the current lifecycle state
+func (c *cron) State() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.state
+}
+
+// Start begins scheduling jobs and moves the cron to StateRunning.
+// With WithElector jobs are scheduled once the cron is elected as the leader.
+// It can be called in StateNew and StateStopped, otherwise ErrCronRunning or ErrCronStopping is returned
+func (c *cron) Start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case StateRunning:
+		return ErrCronRunning
+
+	case StateStopping:
+		return ErrCronStopping
+
+	case StateNew, StateStopped:
+	}
+
+	c.state = StateRunning
+	c.stop = make(chan struct{})
+
+	if c.elector != nil {
+		c.startElection()
+	} else {
+		c.scheduler.start()
+	}
+
+	return nil
 }
 
 // Shutdown stops scheduling and waits for running jobs to finish or context cancellation.
-// It should be called once, next calls without call Start before will be ignored
+// With WithCancelOnShutdown running jobs are cancelled once the context is done.
+// The cron stays in StateStopping until all running jobs finish, even if the context is done earlier.
+// It can be called in StateRunning only, otherwise ErrCronNotRunning is returned
 func (c *cron) Shutdown(ctx context.Context) error {
-	if !c.started.Swap(false) {
+	c.mu.Lock()
+
+	if c.state != StateRunning {
+		c.mu.Unlock()
 		return ErrCronNotRunning
 	}
 
-	c.cron.Stop()
-	return internal.Wait(ctx, c.wg)
+	c.state = StateStopping
+	close(c.stop)
+
+	election := c.election
+	if election != nil {
+		c.stopElection()
+		c.election = nil
+	}
+
+	c.mu.Unlock()
+
+	stopped := c.scheduler.stop()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		// the scheduler may have spawned runs that have not been tracked by c.runs yet
+		<-stopped
+		if election != nil {
+			<-election
+		}
+		<-c.runs.idle()
+
+		c.mu.Lock()
+		c.state = StateStopped
+		c.stop = nil
+		c.mu.Unlock()
+	}()
+
+	err := internal.Wait(ctx, done)
+	if err == nil || c.shutdown.cancelAfter <= 0 {
+		return err
+	}
+
+	return c.cancelRuns(ctx, done)
+}
+
+// stopping returns a channel closed once Shutdown is called; nil unless the cron is running or stopping
+func (c *cron) stopping() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stop
+}
+
+// cancelRuns cancels running jobs and waits for them to return during the cancellation period
+func (c *cron) cancelRuns(ctx context.Context, done <-chan struct{}) error {
+	c.runs.cancel()
+
+	cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.shutdown.cancelAfter)
+	defer cancel()
+
+	if internal.Wait(cancelCtx, done) == nil {
+		return nil
+	}
+
+	return &ShutdownError{
+		Runs: c.runs.refs(),
+		Err:  ctx.Err(),
+	}
 }
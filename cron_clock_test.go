@@ -0,0 +1,615 @@
+package gocron_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/anticrew/gocron/gocrontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron_DefaultHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	clock := gocrontest.NewFakeClock(time.Now())
+	rec := gocrontest.NewRecorder()
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithDefaultHandler(rec))
+	c.MustAdd("@every 1s", func(context.Context) error { return nil }).WithName("job")
+
+	require.NoError(t, c.Start())
+	clock.Advance(time.Second)
+	require.NoError(t, c.Shutdown(ctx))
+
+	assert.NotEmpty(t, rec.Events("job"))
+	assert.Empty(t, rec.Errors())
+}
+
+func TestCron_Start(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	clock := gocrontest.NewFakeClock(time.Now())
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock))
+
+	var called atomic.Int32
+	c.MustAdd("@every 1s", func(context.Context) error {
+		called.Add(1)
+		return nil
+	})
+
+	require.NoError(t, c.Start())
+	for range 10 {
+		require.ErrorIs(t, c.Start(), gocron.ErrCronRunning)
+	}
+
+	clock.Advance(time.Second)
+	require.NoError(t, c.Shutdown(ctx))
+
+	assert.EqualValues(t, 1, called.Load())
+}
+
+func TestCron_GracefulShutdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		expected error
+	}{
+		{
+			name:    "no timeout",
+			timeout: time.Minute,
+		},
+		{
+			name:     "timeout exceeded",
+			timeout:  10 * time.Millisecond,
+			expected: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			clock := gocrontest.NewFakeClock(time.Now())
+
+			var (
+				started  = make(chan struct{})
+				release  = make(chan struct{})
+				advanced = make(chan struct{})
+				finished atomic.Bool
+			)
+
+			c := gocron.NewCron(ctx, gocron.WithClock(clock))
+			c.MustAdd("@every 1s", func(context.Context) error {
+				close(started)
+				<-release
+
+				finished.Store(true)
+				return nil
+			})
+
+			require.NoError(t, c.Start())
+
+			go func() {
+				defer close(advanced)
+				clock.Advance(time.Second)
+			}()
+			<-started
+
+			shutdownCtx, cancel := context.WithTimeout(ctx, tc.timeout)
+			t.Cleanup(cancel)
+
+			shutdown := make(chan error, 1)
+			go func() {
+				shutdown <- c.Shutdown(shutdownCtx)
+			}()
+
+			if tc.expected == nil {
+				close(release)
+				require.NoError(t, <-shutdown)
+				assert.True(t, finished.Load(), "shutdown waits for running jobs")
+			} else {
+				require.ErrorIs(t, <-shutdown, tc.expected)
+				assert.False(t, finished.Load())
+				close(release)
+			}
+
+			<-advanced
+		})
+	}
+}
+
+func TestCron_ScheduledAt(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = t.Context()
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock = gocrontest.NewFakeClock(start)
+		rec   = gocrontest.NewRecorder()
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithDefaultHandler(rec))
+	c.MustAdd("@every 1s", func(context.Context) error { return nil }).WithName("job")
+
+	require.NoError(t, c.Start())
+	clock.Advance(3 * time.Second)
+	require.NoError(t, c.Shutdown(ctx))
+
+	var scheduled []time.Time
+	for _, event := range rec.Events("job") {
+		if event.Stage != gocron.StageExec {
+			continue
+		}
+
+		assert.Equal(t, gocron.TriggerSchedule, event.Trigger)
+		assert.False(t, event.StartedAt.Before(event.ScheduledAt))
+		scheduled = append(scheduled, event.ScheduledAt.UTC())
+	}
+
+	assert.Equal(t, []time.Time{
+		start.Add(time.Second),
+		start.Add(2 * time.Second),
+		start.Add(3 * time.Second),
+	}, scheduled, "each run gets its own fire time")
+}
+
+func TestCron_DefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		timeout          time.Duration
+		expectedDeadline bool
+		expected         error
+	}{
+		{
+			name:             "positive timeout sets a deadline",
+			timeout:          10 * time.Millisecond,
+			expectedDeadline: true,
+			expected:         context.DeadlineExceeded,
+		},
+		{
+			name:    "non-positive timeout does not set a deadline",
+			timeout: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				ctx      = t.Context()
+				clock    = gocrontest.NewFakeClock(time.Now())
+				deadline bool
+				err      error
+			)
+
+			c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithTimeout(tc.timeout))
+			c.MustAdd("@every 1s", func(ctx context.Context) error {
+				if _, deadline = ctx.Deadline(); deadline {
+					<-ctx.Done()
+					err = ctx.Err()
+				}
+
+				return nil
+			})
+
+			require.NoError(t, c.Start())
+			clock.Advance(time.Second)
+			require.NoError(t, c.Shutdown(ctx))
+
+			assert.Equal(t, tc.expectedDeadline, deadline)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestCron_Pause(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = t.Context()
+		clock  = gocrontest.NewFakeClock(time.Now())
+		rec    = gocrontest.NewRecorder()
+		called atomic.Int32
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithDefaultHandler(rec))
+	j := c.MustAdd("@every 1s", func(context.Context) error {
+		called.Add(1)
+		return nil
+	}).WithName("job")
+
+	c.Pause()
+	j.Pause()
+	require.NoError(t, c.Start())
+
+	t.Cleanup(func() {
+		_ = c.Shutdown(ctx)
+	})
+
+	clock.Advance(time.Second)
+
+	event, ok := rec.WaitFor(gocron.StageSkip, "job", 0)
+	require.True(t, ok)
+	assert.ErrorIs(t, event.Error, gocron.ErrJobPaused)
+
+	rec.Reset()
+	j.Resume()
+	clock.Advance(time.Second)
+
+	event, ok = rec.WaitFor(gocron.StageSkip, "job", 0)
+	require.True(t, ok)
+	assert.ErrorIs(t, event.Error, gocron.ErrCronPaused)
+
+	assert.Zero(t, called.Load())
+	require.Len(t, c.Jobs(), 1)
+
+	c.Resume()
+	clock.Advance(time.Second)
+
+	assert.EqualValues(t, 1, called.Load())
+}
+
+func TestCron_State(t *testing.T) {
+	t.Parallel()
+
+	t.Run("restart", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx    = t.Context()
+			clock  = gocrontest.NewFakeClock(time.Now())
+			called atomic.Int32
+		)
+
+		c := gocron.NewCron(ctx, gocron.WithClock(clock))
+		c.MustAdd("@every 1s", func(context.Context) error {
+			called.Add(1)
+			return nil
+		})
+
+		assert.Equal(t, gocron.StateNew, c.State())
+
+		for i := range 3 {
+			require.NoError(t, c.Start())
+			assert.Equal(t, gocron.StateRunning, c.State())
+
+			clock.Advance(time.Second)
+			assert.EqualValues(t, i+1, called.Load())
+
+			require.NoError(t, c.Shutdown(ctx))
+			assert.Equal(t, gocron.StateStopped, c.State())
+
+			require.ErrorIs(t, c.Shutdown(ctx), gocron.ErrCronNotRunning)
+
+			clock.Advance(time.Second)
+			assert.EqualValues(t, i+1, called.Load(), "stopped cron doesn't schedule jobs")
+		}
+	})
+
+	t.Run("stopping", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx      = t.Context()
+			clock    = gocrontest.NewFakeClock(time.Now())
+			started  = make(chan struct{})
+			release  = make(chan struct{})
+			advanced = make(chan struct{})
+		)
+
+		c := gocron.NewCron(ctx, gocron.WithClock(clock))
+		c.MustAdd("@every 1s", func(context.Context) error {
+			close(started)
+			<-release
+
+			return nil
+		})
+
+		require.NoError(t, c.Start())
+
+		go func() {
+			defer close(advanced)
+			clock.Advance(time.Second)
+		}()
+		<-started
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		t.Cleanup(cancel)
+
+		require.ErrorIs(t, c.Shutdown(shutdownCtx), context.DeadlineExceeded)
+		assert.Equal(t, gocron.StateStopping, c.State())
+		require.ErrorIs(t, c.Start(), gocron.ErrCronStopping)
+		require.ErrorIs(t, c.Shutdown(ctx), gocron.ErrCronNotRunning)
+
+		close(release)
+		<-advanced
+
+		require.Eventually(t, func() bool {
+			return c.State() == gocron.StateStopped
+		}, 5*time.Second, time.Millisecond)
+
+		require.NoError(t, c.Start())
+		require.NoError(t, c.Shutdown(ctx))
+	})
+}
+
+func TestCron_CancelOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		stubborn bool
+		expected []string
+	}{
+		{
+			name: "jobs stop after cancellation",
+		},
+		{
+			name:     "stubborn job is reported",
+			stubborn: true,
+			expected: []string{"stubborn"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				ctx       = t.Context()
+				clock     = gocrontest.NewFakeClock(time.Now())
+				started   = make(chan struct{}, 2)
+				release   = make(chan struct{})
+				advanced  = make(chan struct{})
+				cancelled atomic.Int32
+			)
+
+			c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithCancelOnShutdown(100*time.Millisecond))
+			c.MustAdd("@every 1s", func(ctx context.Context) error {
+				started <- struct{}{}
+
+				<-ctx.Done()
+				cancelled.Add(1)
+				return ctx.Err()
+			}).WithName("polite")
+
+			jobs := 1
+			if tc.stubborn {
+				jobs++
+
+				c.MustAdd("@every 1s", func(context.Context) error {
+					started <- struct{}{}
+
+					<-release
+					return nil
+				}).WithName("stubborn")
+			}
+
+			require.NoError(t, c.Start())
+
+			go func() {
+				defer close(advanced)
+				clock.Advance(time.Second)
+			}()
+
+			t.Cleanup(func() {
+				close(release)
+				<-advanced
+			})
+
+			for range jobs {
+				<-started
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			t.Cleanup(cancel)
+
+			err := c.Shutdown(shutdownCtx)
+			assert.EqualValues(t, 1, cancelled.Load())
+
+			if len(tc.expected) == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, gocron.StateStopped, c.State())
+				return
+			}
+
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+
+			var shutdownErr *gocron.ShutdownError
+			require.ErrorAs(t, err, &shutdownErr)
+
+			names := make([]string, 0, len(shutdownErr.Runs))
+			for _, r := range shutdownErr.Runs {
+				names = append(names, r.JobName)
+				assert.NotEmpty(t, r.RunID)
+			}
+
+			assert.Equal(t, tc.expected, names)
+			assert.Equal(t, gocron.StateStopping, c.State())
+		})
+	}
+}
+
+func TestCron_RetryStopsOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = t.Context()
+		clock    = gocrontest.NewFakeClock(time.Now())
+		called   = make(chan struct{})
+		advanced = make(chan struct{})
+		policy   = gocron.RetryPolicy{
+			MaxAttempts: 5,
+			Backoff:     gocron.ConstantBackoff(time.Minute),
+		}
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock))
+	c.MustAdd("@every 1s", func(context.Context) error {
+		close(called)
+		return assert.AnError
+	}).WithRetry(policy)
+
+	require.NoError(t, c.Start())
+
+	go func() {
+		defer close(advanced)
+		clock.Advance(time.Second)
+	}()
+	<-called
+
+	require.NoError(t, c.Shutdown(ctx), "shutdown doesn't wait for the retry delay")
+	<-advanced
+
+	assert.ErrorIs(t, c.Jobs()[0].LastError, assert.AnError)
+}
+
+func TestCron_RemoveRunning(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = t.Context()
+		clock    = gocrontest.NewFakeClock(time.Now())
+		started  = make(chan struct{})
+		release  = make(chan struct{})
+		advanced = make(chan struct{})
+		finished atomic.Int32
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock))
+	j := c.MustAdd("@every 1s", func(context.Context) error {
+		close(started)
+		<-release
+
+		finished.Add(1)
+		return nil
+	})
+
+	require.NoError(t, c.Start())
+
+	go func() {
+		defer close(advanced)
+		clock.Advance(time.Second)
+	}()
+	<-started
+
+	require.NoError(t, j.Remove())
+	close(release)
+	<-advanced
+
+	clock.Advance(time.Second)
+
+	require.NoError(t, c.Shutdown(ctx))
+	assert.EqualValues(t, 1, finished.Load(), "running job finishes, but isn't scheduled anymore")
+}
+
+func TestCron_Jobs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = t.Context()
+		start    = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock    = gocrontest.NewFakeClock(start)
+		started  = make(chan struct{})
+		release  = make(chan struct{})
+		advanced = make(chan struct{})
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock))
+	c.MustAdd("@every 1s", func(context.Context) error {
+		close(started)
+		<-release
+
+		return assert.AnError
+	}).WithName("first")
+	c.MustAdd("@every 1h", func(context.Context) error { return nil }).WithName("second")
+
+	jobs := c.Jobs()
+	require.Len(t, jobs, 2)
+	assert.Zero(t, jobs[0].Next)
+	assert.Zero(t, jobs[1].Next)
+
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		_ = c.Shutdown(ctx)
+	})
+
+	go func() {
+		defer close(advanced)
+		clock.Advance(time.Second)
+	}()
+	<-started
+
+	jobs = c.Jobs()
+	require.Len(t, jobs, 2)
+
+	first := jobs[0]
+	assert.Equal(t, "first", first.Name)
+	assert.Equal(t, "@every 1s", first.Spec)
+	assert.Equal(t, 1, first.Running)
+	assert.Equal(t, start.Add(time.Second), first.Prev.UTC())
+	assert.Equal(t, start.Add(2*time.Second), first.Next.UTC())
+
+	second := jobs[1]
+	assert.Equal(t, "second", second.Name)
+	assert.Zero(t, second.Running)
+	assert.Zero(t, second.Prev)
+	assert.Equal(t, start.Add(time.Hour), second.Next.UTC())
+
+	close(release)
+	<-advanced
+
+	first = c.Jobs()[0]
+	assert.Zero(t, first.Running)
+	assert.ErrorIs(t, first.LastError, assert.AnError)
+	assert.Positive(t, first.LastDuration)
+}
+
+func TestCron_RescheduleRunning(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = t.Context()
+		clock  = gocrontest.NewFakeClock(time.Now())
+		rec    = gocrontest.NewRecorder()
+		called atomic.Int32
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock))
+	j := c.MustAdd("@yearly", func(context.Context) error {
+		called.Add(1)
+		return nil
+	}).WithName("name").WithHandler(rec)
+
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		_ = c.Shutdown(ctx)
+	})
+
+	require.NoError(t, j.Reschedule("@every 1s"))
+
+	jobs := c.Jobs()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "name", jobs[0].Name)
+	assert.Equal(t, "@every 1s", jobs[0].Spec)
+
+	clock.Advance(time.Second)
+	assert.EqualValues(t, 1, called.Load(), "the job runs with the new schedule")
+
+	events := rec.Events("name")
+	require.NotEmpty(t, events)
+	assert.Equal(t, "@every 1s", events[0].JobSpec)
+}
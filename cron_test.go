@@ -4,7 +4,6 @@ import (
 	"context"
 	"sync/atomic"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -51,53 +50,80 @@ func TestCron_Add(t *testing.T) {
 	}
 }
 
-func TestCron_DefaultHandler(t *testing.T) {
+func TestCron_DefaultLockProvider(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
-
-	var called atomic.Bool
-	c := NewCron(ctx, WithDefaultHandler(HandlerFunc(func(event JobEvent) {
-		called.Store(true)
-	})))
+	var (
+		provided []string
+		locks    = map[string]*countingLock{}
+	)
+
+	c := NewCron(t.Context(), WithDefaultLockProvider(func(jobName string) Lock {
+		provided = append(provided, jobName)
+		if jobName == "unlocked" {
+			return nil
+		}
+
+		locks[jobName] = &countingLock{}
+
+		return locks[jobName]
+	}))
+
+	var (
+		cmd      = func(context.Context) error { return nil }
+		job      = c.MustAdd("@every 1h", cmd).WithName("report")
+		explicit = &countingLock{}
+		unlocked = c.MustAdd("@every 1h", cmd).WithName("unlocked")
+	)
+
+	require.NoError(t, c.MustAdd("@every 1h", cmd).WithName("explicit").WithLock(explicit).RunNow(t.Context()))
+	require.NoError(t, job.RunNow(t.Context()))
+	require.NoError(t, job.RunNow(t.Context()))
+	require.NoError(t, job.WithName("export").RunNow(t.Context()))
+	require.NoError(t, unlocked.RunNow(t.Context()))
+	require.NoError(t, unlocked.RunNow(t.Context()))
+
+	assert.Equal(t, []string{"report", "export", "unlocked"}, provided)
+	assert.Equal(t, 2, locks["report"].unlocked)
+	assert.Equal(t, 1, locks["export"].unlocked)
+	assert.Equal(t, 1, explicit.unlocked)
+}
 
-	c.MustAdd("@every 1s", func(context.Context) error { return nil })
+func TestCron_Shutdown(t *testing.T) {
+	t.Parallel()
 
-	c.Start()
+	t.Run("before start", func(t *testing.T) {
+		t.Parallel()
 
-	// TODO: заменить sleep на синхронизацию через канал, тест флейковый под нагрузкой.
-	time.Sleep(time.Second)
+		ctx := t.Context()
+		c := NewCron(ctx)
+		require.ErrorIs(t, c.Shutdown(ctx), ErrCronNotRunning)
+	})
 
-	require.NoError(t, c.Shutdown(ctx))
+	t.Run("several calls", func(t *testing.T) {
+		t.Parallel()
 
-	assert.True(t, called.Load())
+		ctx := t.Context()
+		c := NewCron(ctx)
+		require.NoError(t, c.Start())
+		require.NoError(t, c.Shutdown(ctx))
+		require.ErrorIs(t, c.Shutdown(ctx), ErrCronNotRunning)
+	})
 }
 
-func TestCron_DefaultTimeout(t *testing.T) {
+func TestCronMustAdd(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name     string
-		spec     string
-		timeout  time.Duration
-		job      time.Duration
-		limit    time.Duration
-		expected error
+		name string
+		spec string
+		cmd  Cmd
 	}{
+		// Verifies MustAdd panics on invalid input to match the documented contract.
 		{
-			name:     "positive timeout sets a deadline",
-			spec:     "@every 1s",
-			timeout:  10 * time.Millisecond,
-			job:      100 * time.Millisecond,
-			limit:    time.Second,
-			expected: context.DeadlineExceeded,
-		},
-		{
-			name:    "non-positive timeout does not set a deadline",
-			spec:    "@every 1s",
-			timeout: 0,
-			job:     100 * time.Millisecond,
-			limit:   time.Second,
+			name: "must add panics on invalid spec",
+			spec: "bad spec",
+			cmd:  func(context.Context) error { return nil },
 		},
 	}
 
@@ -105,184 +131,132 @@ func TestCron_DefaultTimeout(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx, cancel := context.WithTimeout(t.Context(), tc.limit)
-			t.Cleanup(cancel)
-
-			c := NewCron(ctx, WithTimeout(tc.timeout))
-
-			errCh := make(chan error, 1)
-			c.MustAdd(tc.spec, func(ctx context.Context) error {
-				time.Sleep(tc.job)
-
-				select {
-				case errCh <- ctx.Err():
-				default:
-				}
-
-				return nil
-			})
-
-			c.Start()
+			cron := NewCron(t.Context())
 
-			t.Cleanup(func() {
-				_ = c.Shutdown(ctx)
+			assert.Panics(t, func() {
+				cron.MustAdd(tc.spec, tc.cmd)
 			})
-
-			var got error
-			select {
-			case got = <-errCh:
-			case <-ctx.Done():
-				t.Fatalf("job did not run in time: %v", ctx.Err())
-			}
-
-			assert.ErrorIs(t, got, tc.expected)
 		})
 	}
 }
 
-func TestCron_Start(t *testing.T) {
+func TestCron_Remove(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
+	t.Run("unknown job", func(t *testing.T) {
+		t.Parallel()
 
-	c := NewCron(ctx)
+		var (
+			ctx   = t.Context()
+			c     = NewCron(ctx)
+			other = NewCron(ctx).MustAdd("@every 1s", func(context.Context) error { return nil })
+		)
 
-	var called atomic.Int32
-	c.MustAdd("@every 1s", func(context.Context) error {
-		called.Add(1)
-		return nil
+		require.ErrorIs(t, c.Remove(other), ErrJobNotFound)
+		require.ErrorIs(t, c.RemoveByName("unknown"), ErrJobNotFound)
 	})
 
-	for range 10 {
-		c.Start()
-		c.Start()
-	}
-
-	// TODO: заменить sleep на синхронизацию через канал, тест флейковый под нагрузкой.
-	time.Sleep(time.Second)
-	require.NoError(t, c.Shutdown(ctx))
+	t.Run("emits event", func(t *testing.T) {
+		t.Parallel()
 
-	assert.EqualValues(t, 1, called.Load())
-}
+		var events []JobEvent
+		c := NewCron(t.Context(), WithDefaultHandler(HandlerFunc(func(event JobEvent) {
+			events = append(events, event)
+		})))
 
-func TestCron_Shutdown(t *testing.T) {
-	t.Parallel()
+		j := c.MustAdd("@every 1s", func(context.Context) error { return nil }).WithName("name")
 
-	t.Run("before start", func(t *testing.T) {
-		t.Parallel()
+		require.NoError(t, c.Remove(j))
+		require.ErrorIs(t, c.Remove(j), ErrJobNotFound)
+		require.ErrorIs(t, j.Remove(), ErrJobNotFound)
 
-		ctx := t.Context()
-		c := NewCron(ctx)
-		require.ErrorIs(t, c.Shutdown(ctx), ErrCronNotRunning)
+		assert.Equal(t, []JobEvent{
+			{
+				JobSpec: "@every 1s",
+				JobName: "name",
+				Stage:   StageRemove,
+			},
+		}, events)
 	})
 
-	t.Run("several calls", func(t *testing.T) {
+	t.Run("by name", func(t *testing.T) {
 		t.Parallel()
 
-		ctx := t.Context()
-		c := NewCron(ctx)
-		c.Start()
-		require.NoError(t, c.Shutdown(ctx))
-		require.ErrorIs(t, c.Shutdown(ctx), ErrCronNotRunning)
+		c := NewCron(t.Context())
+
+		c.MustAdd("@every 1s", func(context.Context) error { return nil }).WithName("tenant")
+		c.MustAdd("@every 1s", func(context.Context) error { return nil }).WithName("tenant")
+		kept := c.MustAdd("@every 1s", func(context.Context) error { return nil }).WithName("other")
+
+		require.NoError(t, c.RemoveByName("tenant"))
+		require.ErrorIs(t, c.RemoveByName("tenant"), ErrJobNotFound)
+		require.NoError(t, kept.Remove())
 	})
 }
 
-func TestCron_GracefulShutdown(t *testing.T) {
+func TestCron_Trigger(t *testing.T) {
 	t.Parallel()
 
-	tests := []struct {
-		name     string
-		spec     string
-		job      time.Duration
-		wait     time.Duration
-		timeout  time.Duration
-		expected error
-	}{
-		{
-			name:    "no timeout",
-			spec:    "@every 1s",
-			job:     3 * time.Second,
-			wait:    10 * time.Second,
-			timeout: time.Minute,
-		},
-		{
-			name:     "timeout exceeded",
-			spec:     "@every 1s",
-			job:      3 * time.Second,
-			wait:     10 * time.Second,
-			timeout:  1 * time.Second,
-			expected: context.DeadlineExceeded,
-		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-
-			ctx := t.Context()
-
-			var (
-				started  atomic.Int32
-				finished atomic.Int32
-			)
-
-			c := NewCron(ctx)
-			c.MustAdd(tc.spec, func(ctx context.Context) error {
-				started.Add(1)
+	ctx := t.Context()
 
-				time.Sleep(tc.job)
+	var called atomic.Int32
 
-				if err := ctx.Err(); err != nil {
-					return err
-				}
+	c := NewCron(ctx)
+	c.MustAdd("@yearly", func(context.Context) error {
+		called.Add(1)
+		return nil
+	}).WithName("export")
+	c.MustAdd("@yearly", func(context.Context) error {
+		called.Add(1)
+		return assert.AnError
+	}).WithName("failing")
 
-				finished.Add(1)
-				return nil
-			})
+	require.NoError(t, c.Trigger(ctx, "export"))
+	require.ErrorIs(t, c.Trigger(ctx, "failing"), assert.AnError)
+	require.ErrorIs(t, c.Trigger(ctx, "unknown"), ErrJobNotFound)
 
-			c.Start()
+	assert.EqualValues(t, 2, called.Load())
+}
 
-			// TODO: заменить sleep на синхронизацию через канал, тест флейковый под нагрузкой.
-			time.Sleep(tc.wait)
+func TestCron_FindByName(t *testing.T) {
+	t.Parallel()
 
-			shutdownCtx, cancel := context.WithTimeout(ctx, tc.timeout)
-			t.Cleanup(cancel)
+	c := NewCron(t.Context())
+	first := c.MustAdd("@yearly", func(context.Context) error { return nil }).WithName("export")
+	second := c.MustAdd("@yearly", func(context.Context) error { return nil }).WithName("export")
+	c.MustAdd("@yearly", func(context.Context) error { return nil }).WithName("other")
 
-			require.ErrorIs(t, c.Shutdown(shutdownCtx), tc.expected)
+	assert.Equal(t, []Job{first, second}, c.FindByName("export"))
+	assert.Nil(t, c.FindByName("unknown"))
 
-			assert.NotZero(t, started.Load())
-			if tc.expected == nil {
-				assert.Equal(t, started.Load(), finished.Load())
-			}
-		})
-	}
+	require.NoError(t, first.Remove())
+	assert.Equal(t, []Job{second}, c.FindByName("export"))
 }
 
-func TestCronMustAdd(t *testing.T) {
+func TestCron_Reschedule(t *testing.T) {
 	t.Parallel()
 
-	tests := []struct {
-		name string
-		spec string
-		cmd  Cmd
-	}{
-		// Verifies MustAdd panics on invalid input to match the documented contract.
-		{
-			name: "must add panics on invalid spec",
-			spec: "bad spec",
-			cmd:  func(context.Context) error { return nil },
-		},
-	}
+	t.Run("uses configured parser", func(t *testing.T) {
+		t.Parallel()
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		var (
+			ctx      = t.Context()
+			c        = NewCron(ctx)
+			cSeconds = NewCron(ctx, WithSeconds())
+			cmd      = func(context.Context) error { return nil }
+		)
 
-			cron := NewCron(t.Context())
+		const spec = "*/5 * * * * *"
 
-			assert.Panics(t, func() {
-				cron.MustAdd(tc.spec, tc.cmd)
-			})
-		})
-	}
+		j := c.MustAdd("@yearly", cmd)
+		assert.ErrorContains(t, j.Reschedule(spec), "cron.AddJob")
+		assert.Equal(t, "@yearly", c.Jobs()[0].Spec)
+
+		j = cSeconds.MustAdd("@yearly", cmd)
+		require.NoError(t, j.Reschedule(spec))
+		assert.Equal(t, spec, cSeconds.Jobs()[0].Spec)
+
+		require.NoError(t, j.Remove())
+		require.ErrorIs(t, j.Reschedule(spec), ErrJobNotFound)
+	})
 }
@@ -0,0 +1,101 @@
+package gocron
+
+import (
+	"context"
+	"time"
+)
+
+// electionRetryDelay is the delay before the next Elector.Elect call after a failed one
+const electionRetryDelay = time.Second
+
+// WithElector enables leader election: the cron schedules jobs only while elected by the elector.
+// Once the leadership is lost, scheduling stops, scheduled runs are cancelled and awaited, and the cron runs for election again.
+// Manual runs are not affected by the leadership: they run on followers and aren't cancelled on leadership loss
+func WithElector(e Elector) Option {
+	return func(o *optionsHolder) {
+		o.elector = e
+	}
+}
+
+// Leader reports whether the cron schedules jobs: it's running and, with WithElector, elected as the leader
+func (c *cron) Leader() bool {
+	if c.elector == nil {
+		return c.State() == StateRunning
+	}
+
+	return c.leader.Load()
+}
+
+// startElection starts the election loop stopped by Shutdown; c.mu must be held
+func (c *cron) startElection() {
+	ctx, cancel := context.WithCancel(c.baseCtx)
+
+	c.stopElection = cancel
+	c.election = make(chan struct{})
+
+	go c.elect(ctx, c.election)
+}
+
+// elect runs for election until ctx is done and schedules jobs while elected
+func (c *cron) elect(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+
+	for ctx.Err() == nil {
+		leaderCtx, err := c.elector.Elect(ctx)
+		if err != nil {
+			c.waitRetry(ctx)
+			continue
+		}
+
+		c.lead(ctx, leaderCtx)
+	}
+}
+
+// waitRetry waits for electionRetryDelay on the cron clock or until ctx is done
+func (c *cron) waitRetry(ctx context.Context) {
+	retry := make(chan struct{})
+	timer := c.clock.AfterFunc(electionRetryDelay, func() {
+		close(retry)
+	})
+
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+	case <-retry:
+	}
+}
+
+// lead schedules jobs until the leadership is lost or ctx is done, then waits for scheduled runs and resigns.
+// Resign errors are ignored: the leadership is lost anyway
+func (c *cron) lead(ctx, leaderCtx context.Context) {
+	defer func() {
+		_ = c.elector.Resign(context.WithoutCancel(ctx))
+	}()
+
+	c.mu.Lock()
+	if c.state != StateRunning {
+		c.mu.Unlock()
+		return
+	}
+
+	// the leadership is visible to jobs of the first scheduled runs
+	c.leader.Store(true)
+	c.scheduler.start()
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+	case <-leaderCtx.Done():
+	}
+
+	c.leader.Store(false)
+	stopped := c.scheduler.stop()
+
+	// on shutdown, running jobs are cancelled by Shutdown if requested
+	if ctx.Err() == nil {
+		c.runs.cancelScheduled()
+	}
+
+	<-stopped
+	<-c.runs.scheduledIdle()
+}
@@ -0,0 +1,145 @@
+package gocron_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/anticrew/gocron/gocrontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// grantElector elects the cron once a leadership context is sent to grant
+type grantElector struct {
+	grant    chan context.Context
+	resigned atomic.Int32
+}
+
+func (e *grantElector) Elect(ctx context.Context) (context.Context, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case leaderCtx := <-e.grant:
+		return leaderCtx, nil
+	}
+}
+
+func (e *grantElector) Resign(context.Context) error {
+	e.resigned.Add(1)
+	return nil
+}
+
+func TestCron_Elector(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = t.Context()
+		clock   = gocrontest.NewFakeClock(time.Now())
+		elector = &grantElector{grant: make(chan context.Context)}
+		started = make(chan gocron.Trigger, 2)
+		stopped = make(chan gocron.Trigger, 2)
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithElector(elector))
+	job := c.MustAdd("@every 1s", func(ctx context.Context) error {
+		info, _ := gocron.RunInfoFromContext(ctx)
+
+		started <- info.Trigger
+		<-ctx.Done()
+		stopped <- info.Trigger
+
+		return nil
+	})
+
+	assert.False(t, c.Leader())
+	require.NoError(t, c.Start())
+	assert.False(t, c.Leader())
+
+	clock.Advance(time.Second)
+	assert.Empty(t, started, "followers don't schedule jobs")
+
+	manualCtx, stopManual := context.WithCancel(ctx)
+	manual := make(chan error, 1)
+	go func() {
+		manual <- job.RunNow(manualCtx)
+	}()
+	assert.Equal(t, gocron.TriggerManual, <-started, "followers run jobs manually")
+
+	leaderCtx, lose := context.WithCancel(ctx)
+	elector.grant <- leaderCtx
+	require.Eventually(t, c.Leader, time.Second, time.Millisecond)
+
+	advanced := make(chan struct{})
+	go func() {
+		defer close(advanced)
+		clock.Advance(time.Second)
+	}()
+	assert.Equal(t, gocron.TriggerSchedule, <-started)
+
+	lose()
+
+	assert.Equal(t, gocron.TriggerSchedule, <-stopped, "scheduled runs are cancelled on leadership loss")
+	<-advanced
+
+	require.Eventually(t, func() bool { return elector.resigned.Load() == 1 }, time.Second, time.Millisecond)
+	assert.False(t, c.Leader())
+	assert.Empty(t, stopped, "manual runs are not cancelled on leadership loss")
+
+	stopManual()
+	assert.Equal(t, gocron.TriggerManual, <-stopped)
+	require.NoError(t, <-manual)
+
+	elector.grant <- ctx
+	require.Eventually(t, c.Leader, time.Second, time.Millisecond)
+
+	require.NoError(t, c.Shutdown(ctx))
+	assert.Equal(t, int32(2), elector.resigned.Load())
+	assert.False(t, c.Leader())
+	assert.Equal(t, gocron.StateStopped, c.State())
+}
+
+// flakyElector fails the first election and then elects the cron until shutdown
+type flakyElector struct {
+	calls atomic.Int32
+}
+
+func (e *flakyElector) Elect(ctx context.Context) (context.Context, error) {
+	if e.calls.Add(1) == 1 {
+		return nil, errors.New("unavailable")
+	}
+
+	return ctx, nil
+}
+
+func (e *flakyElector) Resign(context.Context) error {
+	return nil
+}
+
+func TestCron_ElectorRetry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = t.Context()
+		clock   = gocrontest.NewFakeClock(time.Now())
+		elector = &flakyElector{}
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithElector(elector))
+	require.NoError(t, c.Start())
+
+	require.Eventually(t, func() bool { return elector.calls.Load() == 1 }, time.Second, time.Millisecond)
+	assert.False(t, c.Leader())
+
+	// the retry delay is a second of the cron clock, real time would take longer than the wait
+	require.Eventually(t, func() bool {
+		clock.Advance(time.Second)
+		return c.Leader()
+	}, 500*time.Millisecond, time.Millisecond)
+	assert.Equal(t, int32(2), elector.calls.Load())
+
+	require.NoError(t, c.Shutdown(ctx))
+}
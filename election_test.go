@@ -0,0 +1,21 @@
+package gocron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron_Leader(t *testing.T) {
+	t.Parallel()
+
+	c := NewCron(t.Context())
+	assert.False(t, c.Leader())
+
+	require.NoError(t, c.Start())
+	assert.True(t, c.Leader())
+
+	require.NoError(t, c.Shutdown(t.Context()))
+	assert.False(t, c.Leader())
+}
@@ -0,0 +1,117 @@
+// Package elector provides gocron.Elector implementations
+package elector
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/anticrew/gocron"
+)
+
+// Memory elects the leader among candidates in one process, e.g. crons emulating replicas in tests.
+// Once the leader resigns or is deposed, the leadership passes to the longest waiting candidate
+type Memory struct {
+	mu      sync.Mutex
+	leader  *candidate
+	waiting []*candidate
+}
+
+// NewMemory creates an in-memory election
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Candidate returns a new elector taking part in the election; use one candidate per cron
+func (m *Memory) Candidate() gocron.Elector {
+	return &candidate{memory: m}
+}
+
+// Depose makes the current leader lose the leadership, so another candidate may be elected
+func (m *Memory) Depose() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.leader != nil {
+		m.release()
+	}
+}
+
+// elect makes c the leader; m.mu must be held
+func (m *Memory) elect(c *candidate) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c.cancel = cancel
+	m.leader = c
+
+	return ctx
+}
+
+// release passes the leadership to the longest waiting candidate; m.mu must be held
+func (m *Memory) release() {
+	m.leader.cancel()
+	m.leader = nil
+
+	if len(m.waiting) == 0 {
+		return
+	}
+
+	next := m.waiting[0]
+	m.waiting = m.waiting[1:]
+
+	next.elected <- m.elect(next)
+}
+
+type candidate struct {
+	memory  *Memory
+	cancel  context.CancelFunc
+	elected chan context.Context
+}
+
+// Elect blocks until the candidate is elected or ctx is done
+func (c *candidate) Elect(ctx context.Context) (context.Context, error) {
+	m := c.memory
+
+	m.mu.Lock()
+
+	if m.leader == nil && len(m.waiting) == 0 {
+		defer m.mu.Unlock()
+		return m.elect(c), nil
+	}
+
+	c.elected = make(chan context.Context, 1)
+	m.waiting = append(m.waiting, c)
+	m.mu.Unlock()
+
+	select {
+	case leaderCtx := <-c.elected:
+		return leaderCtx, nil
+
+	case <-ctx.Done():
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.waiting = slices.DeleteFunc(m.waiting, func(w *candidate) bool { return w == c })
+
+		// the candidate may be elected concurrently
+		if m.leader == c {
+			m.release()
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+// Resign gives up the leadership if the candidate is the leader
+func (c *candidate) Resign(context.Context) error {
+	m := c.memory
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.leader == c {
+		m.release()
+	}
+
+	return nil
+}
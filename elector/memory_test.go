@@ -0,0 +1,85 @@
+package elector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	var (
+		memory = NewMemory()
+		first  = memory.Candidate()
+		second = memory.Candidate()
+	)
+
+	leaderCtx, err := first.Elect(t.Context())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = second.Elect(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	elected := make(chan context.Context)
+	go func() {
+		secondCtx, _ := second.Elect(t.Context())
+		elected <- secondCtx
+	}()
+
+	require.NoError(t, first.Resign(t.Context()))
+	require.ErrorIs(t, leaderCtx.Err(), context.Canceled)
+
+	secondCtx := <-elected
+	require.NotNil(t, secondCtx)
+	require.NoError(t, secondCtx.Err())
+
+	require.NoError(t, first.Resign(t.Context()), "resign of a non-leader is no-op")
+	require.NoError(t, secondCtx.Err())
+
+	memory.Depose()
+	require.ErrorIs(t, secondCtx.Err(), context.Canceled)
+
+	leaderCtx, err = first.Elect(t.Context())
+	require.NoError(t, err)
+	assert.NoError(t, leaderCtx.Err())
+}
+
+func TestMemory_Cron(t *testing.T) {
+	t.Parallel()
+
+	var (
+		memory = NewMemory()
+		first  = gocron.NewCron(t.Context(), gocron.WithElector(memory.Candidate()))
+		second = gocron.NewCron(t.Context(), gocron.WithElector(memory.Candidate()))
+	)
+
+	require.NoError(t, first.Start())
+	require.Eventually(t, first.Leader, time.Second, time.Millisecond)
+
+	require.NoError(t, second.Start())
+	assert.False(t, second.Leader())
+
+	// the second cron may not be running for election yet
+	require.Eventually(t, func() bool {
+		if second.Leader() {
+			return true
+		}
+
+		memory.Depose()
+
+		return false
+	}, time.Second, 50*time.Millisecond)
+	assert.False(t, first.Leader())
+
+	require.NoError(t, second.Shutdown(t.Context()))
+	require.Eventually(t, first.Leader, time.Second, time.Millisecond)
+	require.NoError(t, first.Shutdown(t.Context()))
+}
@@ -1,8 +1,73 @@
 package gocron
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	ErrCommandIsNil   = errors.New("command is nil")
 	ErrCronNotRunning = errors.New("cron is not running")
+	ErrCronRunning    = errors.New("cron is already running")
+	ErrCronStopping   = errors.New("cron is stopping")
+	ErrJobNotFound    = errors.New("job not found")
+	ErrJobPaused      = errors.New("job is paused")
+	ErrCronPaused     = errors.New("cron is paused")
+	ErrJobRunning     = errors.New("job is already running")
+	ErrNotLeader      = errors.New("cron is not the leader")
+	ErrLimitReached   = errors.New("concurrency limit reached")
+
+	// ErrLockNotAcquired should be returned by Lock.Lock when the lock is held elsewhere.
+	// Such runs are reported with StageSkip instead of a StageStart error
+	ErrLockNotAcquired = errors.New("lock not acquired")
+
+	// ErrLockNotHeld should be returned by Lock.Unlock and RenewableLock.Refresh when the run doesn't hold the lock,
+	// e.g. the lease expired and the lock was acquired elsewhere
+	ErrLockNotHeld = errors.New("lock is not held")
+
+	// ErrNoLockName is returned by LockName when neither the lock nor the run provide a name
+	ErrNoLockName = errors.New("lock name is not set")
+
+	// ErrLockLost is the cause of the command context cancellation when RenewableLock.Refresh fails
+	ErrLockLost = errors.New("lock lost")
 )
+
+// ShutdownError is returned by Shutdown when running jobs did not return after their cancellation
+type ShutdownError struct {
+	// Runs lists invocations that were still running
+	Runs []RunRef
+	// Err is the error of the Shutdown context
+	Err error
+}
+
+func (e *ShutdownError) Error() string {
+	runs := make([]string, 0, len(e.Runs))
+	for _, r := range e.Runs {
+		runs = append(runs, fmt.Sprintf("%s (run %s)", r.JobName, r.RunID))
+	}
+
+	return fmt.Sprintf("jobs did not stop: %s: %v", strings.Join(runs, ", "), e.Err)
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is reported at StagePanic when a job command panics
+type PanicError struct {
+	// Value is the value passed to panic
+	Value any
+	// Stack is the stack trace of the panicking goroutine
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("job panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
@@ -0,0 +1,57 @@
+package gocron
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownError(t *testing.T) {
+	t.Parallel()
+
+	err := &ShutdownError{
+		Runs: []RunRef{
+			{JobName: "export", RunID: "a"},
+			{JobName: "import", RunID: "b"},
+		},
+		Err: context.DeadlineExceeded,
+	}
+
+	assert.EqualError(t, err, "jobs did not stop: export (run a), import (run b): context deadline exceeded")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPanicError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     any
+		expected  string
+		unwrapped error
+	}{
+		{
+			name:     "value",
+			value:    42,
+			expected: "job panicked: 42",
+		},
+		{
+			name:      "error",
+			value:     context.Canceled,
+			expected:  "job panicked: context canceled",
+			unwrapped: context.Canceled,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := &PanicError{Value: tc.value}
+
+			assert.EqualError(t, err, tc.expected)
+			assert.Equal(t, tc.unwrapped, err.Unwrap())
+		})
+	}
+}
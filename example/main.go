@@ -43,7 +43,10 @@ func main() {
 		return ctx.Err()
 	}).WithName("1s timeout")
 
-	c.Start()
+	if err := c.Start(); err != nil {
+		l.Error("can't start cron", slog.Any("error", err))
+		return
+	}
 
 	<-notifyCtx.Done()
 
@@ -0,0 +1,98 @@
+package filelock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/anticrew/gocron"
+)
+
+// DefaultPollInterval is the default interval of lock attempts while running for election
+const DefaultPollInterval = time.Second
+
+var _ gocron.Elector = (*Elector)(nil)
+
+// Elector is a gocron.Elector electing the process holding flock(2) on the file as the leader among processes on one host.
+// The leadership is held until Resign or the process exit
+type Elector struct {
+	path string
+	poll time.Duration
+
+	mu     sync.Mutex
+	file   *os.File
+	cancel context.CancelFunc
+}
+
+// NewElector creates an elector locking the file at path. The directory is created on first use if needed
+func NewElector(path string) *Elector {
+	return &Elector{
+		path: path,
+		poll: DefaultPollInterval,
+	}
+}
+
+// WithPollInterval sets the interval of lock attempts while running for election
+func (e *Elector) WithPollInterval(d time.Duration) *Elector {
+	e.poll = d
+	return e
+}
+
+// Elect tries to lock the file every poll interval until it succeeds or ctx is done.
+// The returned context is done once Resign is called
+func (e *Elector) Elect(ctx context.Context) (context.Context, error) {
+	if err := os.MkdirAll(filepath.Dir(e.path), dirPerm); err != nil {
+		return nil, fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Clean(e.path), os.O_RDWR|os.O_CREATE, filePerm)
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile: %w", err)
+	}
+
+	for {
+		err = tryLock(f)
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, errLocked) {
+			return nil, errors.Join(fmt.Errorf("flock: %w", err), f.Close())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Join(ctx.Err(), f.Close())
+		case <-time.After(e.poll):
+		}
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.file, e.cancel = f, cancel
+
+	return leaderCtx, nil
+}
+
+// Resign unlocks the file if the elector holds it
+func (e *Elector) Resign(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file == nil {
+		return nil
+	}
+
+	e.cancel()
+	err := errors.Join(unlock(e.file), e.file.Close())
+	e.file, e.cancel = nil, nil
+
+	return err
+}
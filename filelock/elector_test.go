@@ -0,0 +1,44 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package filelock
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestElector(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path   = filepath.Join(t.TempDir(), "leader", "report.lock")
+		first  = NewElector(path).WithPollInterval(time.Millisecond)
+		second = NewElector(path).WithPollInterval(time.Millisecond)
+	)
+
+	leaderCtx, err := first.Elect(t.Context())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = second.Elect(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	elected := make(chan error)
+	go func() {
+		_, err := second.Elect(t.Context())
+		elected <- err
+	}()
+
+	require.NoError(t, first.Resign(t.Context()))
+	require.ErrorIs(t, leaderCtx.Err(), context.Canceled)
+	require.NoError(t, first.Resign(t.Context()), "resign of a non-leader is no-op")
+
+	require.NoError(t, <-elected)
+	require.NoError(t, second.Resign(t.Context()))
+}
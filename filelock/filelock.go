@@ -0,0 +1,129 @@
+// Package filelock provides a gocron.Lock and a gocron.Elector backed by flock(2) for processes sharing one host
+package filelock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/anticrew/gocron"
+)
+
+// errLocked is returned by tryLock when the file is locked elsewhere
+var errLocked = errors.New("file is locked")
+
+const (
+	dirPerm  = 0o750
+	filePerm = 0o600
+	fileExt  = ".lock"
+)
+
+var _ gocron.Lock = (*Lock)(nil)
+
+// Lock is a non-blocking gocron.Lock using flock(2) on a per-job file in a directory.
+// The file name is derived from the job name, so jobs must have the same name in all processes.
+// The kernel releases the lock if the process dies, the lock file itself is never removed
+type Lock struct {
+	dir  string
+	name string
+
+	mu    sync.Mutex
+	files map[runLock]*os.File
+}
+
+// runLock identifies a lock file held by a run, a run ID alone is not unique across jobs sharing the lock
+type runLock struct {
+	name  string
+	runID string
+}
+
+// New creates a lock keeping lock files in dir. The directory is created on first use if needed
+func New(dir string) *Lock {
+	return &Lock{
+		dir:   dir,
+		files: make(map[runLock]*os.File),
+	}
+}
+
+// WithName sets the name the lock file is derived from instead of the job name
+func (l *Lock) WithName(name string) *Lock {
+	l.name = name
+	return l
+}
+
+// Path returns the lock file path of the given job name
+func (l *Lock) Path(name string) string {
+	if l.name != "" {
+		name = l.name
+	}
+
+	return filepath.Join(l.dir, url.PathEscape(name)+fileExt)
+}
+
+// Lock tries to acquire the lock file of the job running in ctx without blocking.
+// It returns gocron.ErrLockNotAcquired if the file is locked by another process or run
+func (l *Lock) Lock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	name, err := gocron.LockName(ctx, l.name)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(l.dir, dirPerm); err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	info, _ := gocron.RunInfoFromContext(ctx)
+	path := l.Path(name)
+
+	f, err := os.OpenFile(filepath.Clean(path), os.O_RDWR|os.O_CREATE, filePerm)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+
+	if err = tryLock(f); err != nil {
+		_ = f.Close()
+
+		if errors.Is(err, errLocked) {
+			return fmt.Errorf("%w: %s", gocron.ErrLockNotAcquired, path)
+		}
+
+		return fmt.Errorf("flock: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.files[runLock{name: name, runID: info.RunID}] = f
+
+	return nil
+}
+
+// Unlock releases the lock file held by the run in ctx
+func (l *Lock) Unlock(ctx context.Context) error {
+	name, err := gocron.LockName(ctx, l.name)
+	if err != nil {
+		return err
+	}
+
+	info, _ := gocron.RunInfoFromContext(ctx)
+	key := runLock{name: name, runID: info.RunID}
+
+	l.mu.Lock()
+	f, ok := l.files[key]
+	delete(l.files, key)
+	l.mu.Unlock()
+
+	if !ok {
+		return gocron.ErrLockNotHeld
+	}
+
+	return errors.Join(unlock(f), f.Close())
+}
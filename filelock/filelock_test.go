@@ -0,0 +1,198 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package filelock
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anticrew/gocron"
+	"github.com/anticrew/gocron/gocrontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const helperDirEnv = "FILELOCK_HELPER_DIR"
+
+// TestHelperProcess tries to lock the "report" job, reports the result and holds the lock until stdin is closed.
+// It's run by startHelper only
+func TestHelperProcess(t *testing.T) {
+	dir := os.Getenv(helperDirEnv)
+	if dir == "" {
+		t.Skip("helper process")
+	}
+
+	result := "locked"
+	if err := New(dir).Lock(gocrontest.RunContext(t.Context(), "report", "helper")); err != nil {
+		result = err.Error()
+	}
+
+	_, _ = os.Stdout.WriteString(result + "\n")
+	_, _ = io.Copy(io.Discard, os.Stdin)
+
+	// exit without Unlock: the kernel releases the lock with the process
+	os.Exit(0)
+}
+
+// startHelper starts TestHelperProcess in another process and returns its lock result and a function stopping it
+func startHelper(t *testing.T, dir string) (string, func()) {
+	t.Helper()
+
+	cmd := exec.CommandContext(t.Context(), os.Args[0], "-test.run=^TestHelperProcess$") //nolint:gosec // the test binary itself
+	cmd.Env = append(os.Environ(), helperDirEnv+"="+dir)
+
+	stdin, err := cmd.StdinPipe()
+	require.NoError(t, err)
+
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+
+	require.NoError(t, cmd.Start())
+
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	require.NoError(t, err)
+
+	return strings.TrimSuffix(line, "\n"), func() {
+		require.NoError(t, stdin.Close())
+		require.NoError(t, cmd.Wait())
+	}
+}
+
+func TestLock_Processes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir     = t.TempDir()
+		lock    = New(dir)
+		ctx     = gocrontest.RunContext(t.Context(), "report", "run")
+		cleanup = gocrontest.RunContext(t.Context(), "cleanup", "run")
+	)
+
+	result, stop := startHelper(t, dir)
+	require.Equal(t, "locked", result)
+
+	require.ErrorIs(t, lock.Lock(ctx), gocron.ErrLockNotAcquired)
+	require.NoError(t, lock.Lock(cleanup), "other jobs are not locked")
+
+	stop()
+
+	require.NoError(t, lock.Lock(ctx), "lock is released when the holder exits")
+	require.NoError(t, lock.Unlock(cleanup), "locks of jobs with the same run ID are held separately")
+
+	result, stop = startHelper(t, dir)
+	assert.Contains(t, result, gocron.ErrLockNotAcquired.Error())
+	stop()
+
+	require.NoError(t, lock.Unlock(ctx))
+
+	result, stop = startHelper(t, dir)
+	assert.Equal(t, "locked", result)
+	stop()
+}
+
+func TestLock_Runs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lock   = New(t.TempDir())
+		first  = gocrontest.RunContext(t.Context(), "report", "first")
+		second = gocrontest.RunContext(t.Context(), "report", "second")
+	)
+
+	require.NoError(t, lock.Lock(first))
+	require.ErrorIs(t, lock.Lock(second), gocron.ErrLockNotAcquired)
+	require.ErrorIs(t, lock.Unlock(second), gocron.ErrLockNotHeld)
+
+	require.NoError(t, lock.Unlock(first))
+	require.NoError(t, lock.Lock(second))
+	require.NoError(t, lock.Unlock(second))
+}
+
+func TestLock_Name(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		lock     *Lock
+		ctx      context.Context
+		expected string
+		err      error
+	}{
+		{
+			name:     "uses job name",
+			lock:     New(dir),
+			ctx:      gocrontest.RunContext(t.Context(), "report", "run"),
+			expected: filepath.Join(dir, "report.lock"),
+		},
+		{
+			name:     "escapes job name",
+			lock:     New(dir),
+			ctx:      gocrontest.RunContext(t.Context(), "../daily report", "run"),
+			expected: filepath.Join(dir, "..%2Fdaily%20report.lock"),
+		},
+		{
+			name:     "prefers lock name",
+			lock:     New(dir).WithName("shared"),
+			ctx:      gocrontest.RunContext(t.Context(), "report", "run"),
+			expected: filepath.Join(dir, "shared.lock"),
+		},
+		{
+			name: "requires name",
+			lock: New(dir),
+			ctx:  t.Context(),
+			err:  gocron.ErrNoLockName,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.lock.Lock(tc.ctx)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			defer func() { require.NoError(t, tc.lock.Unlock(tc.ctx)) }()
+
+			info, _ := gocron.RunInfoFromContext(tc.ctx)
+			assert.Equal(t, tc.expected, tc.lock.Path(info.JobName))
+			assert.FileExists(t, tc.expected)
+		})
+	}
+}
+
+func TestLock_Job(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir    = t.TempDir()
+		called bool
+	)
+
+	job := gocron.NewCron(t.Context()).MustAdd("@every 1h", func(context.Context) error {
+		called = true
+		return nil
+	}).WithName("report").WithLock(New(dir))
+
+	result, stop := startHelper(t, dir)
+	require.Equal(t, "locked", result)
+
+	require.ErrorIs(t, job.RunNow(t.Context()), gocron.ErrLockNotAcquired)
+	assert.False(t, called)
+
+	stop()
+
+	require.NoError(t, job.RunNow(t.Context()))
+	assert.True(t, called)
+}
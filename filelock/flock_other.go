@@ -0,0 +1,16 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package filelock
+
+import (
+	"errors"
+	"os"
+)
+
+func tryLock(*os.File) error {
+	return errors.ErrUnsupported
+}
+
+func unlock(*os.File) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,148 @@
+// Package gocrontest provides utilities for testing cron-driven code
+package gocrontest
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/anticrew/gocron"
+)
+
+var _ gocron.Clock = (*FakeClock)(nil)
+
+// FakeClock is a gocron.Clock moved by the test only. Pass it to gocron.WithClock to run jobs deterministically:
+//
+//	clock := gocrontest.NewFakeClock(time.Now())
+//	c := gocron.NewCron(ctx, gocron.WithClock(clock))
+//	c.MustAdd("@every 1m", cmd)
+//	_ = c.Start()
+//	clock.Advance(time.Minute) // returns once cmd returns
+type FakeClock struct {
+	advance sync.Mutex
+
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock creates a fake clock showing now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current fake time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc schedules the call of f once the fake time is moved by d.
+// Calls with non-positive d are made by the next Advance
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) gocron.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{
+		clock: c,
+		when:  c.now.Add(d),
+		f:     f,
+	}
+	c.timers = append(c.timers, t)
+
+	return t
+}
+
+// Advance moves the fake time by d and makes the due calls in time order, so scheduled jobs run.
+// Calls due at the same time run concurrently, and Advance returns once all of them return,
+// i.e. all due job runs are complete. Concurrent Advance calls are serialized;
+// don't call Advance from jobs, it deadlocks
+func (c *FakeClock) Advance(d time.Duration) {
+	c.advance.Lock()
+	defer c.advance.Unlock()
+
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		due := c.due(target)
+		if len(due) == 0 {
+			break
+		}
+
+		var wg sync.WaitGroup
+		for _, t := range due {
+			wg.Go(t.f)
+		}
+
+		wg.Wait()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.now.Before(target) {
+		c.now = target
+	}
+}
+
+// due removes the earliest calls due until target, moves the time to them and returns them
+func (c *FakeClock) due(target time.Time) []*fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		when  time.Time
+		found bool
+	)
+
+	for _, t := range c.timers {
+		if !t.when.After(target) && (!found || t.when.Before(when)) {
+			when, found = t.when, true
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	if when.After(c.now) {
+		c.now = when
+	}
+
+	var due []*fakeTimer
+	c.timers = slices.DeleteFunc(c.timers, func(t *fakeTimer) bool {
+		if t.when.After(c.now) {
+			return false
+		}
+
+		due = append(due, t)
+
+		return true
+	})
+
+	return due
+}
+
+// fakeTimer is a call scheduled by FakeClock.AfterFunc
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	f     func()
+}
+
+// Stop cancels the call unless it has already been made
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := len(c.timers)
+	c.timers = slices.DeleteFunc(c.timers, func(other *fakeTimer) bool { return other == t })
+
+	return len(c.timers) < n
+}
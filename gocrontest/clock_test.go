@@ -0,0 +1,85 @@
+package gocrontest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClock(t *testing.T) {
+	t.Parallel()
+
+	var (
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock = NewFakeClock(start)
+		mu    sync.Mutex
+		calls []time.Duration
+	)
+
+	call := func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls = append(calls, clock.Now().Sub(start))
+	}
+
+	clock.AfterFunc(3*time.Second, call)
+	clock.AfterFunc(time.Second, call)
+	stopped := clock.AfterFunc(2*time.Second, call)
+
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, []time.Duration{time.Second}, calls)
+	assert.Equal(t, start.Add(time.Second), clock.Now())
+
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, []time.Duration{time.Second, 3 * time.Second}, calls)
+	assert.Equal(t, start.Add(6*time.Second), clock.Now())
+}
+
+func TestFakeClock_Cron(t *testing.T) {
+	t.Parallel()
+
+	var (
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock = NewFakeClock(start)
+		runs  []gocron.RunInfo
+	)
+
+	c := gocron.NewCron(t.Context(), gocron.WithClock(clock))
+	c.MustAdd("@every 1m", func(ctx context.Context) error {
+		info, _ := gocron.RunInfoFromContext(ctx)
+		runs = append(runs, info)
+
+		return nil
+	})
+
+	require.NoError(t, c.Start())
+
+	clock.Advance(59 * time.Second)
+	assert.Empty(t, runs)
+
+	clock.Advance(time.Second)
+	require.Len(t, runs, 1)
+	assert.WithinDuration(t, start.Add(time.Minute), runs[0].ScheduledAt, 0)
+	assert.WithinDuration(t, start.Add(time.Minute), runs[0].StartedAt, 0)
+
+	clock.Advance(3 * time.Minute)
+	require.Len(t, runs, 4)
+	assert.WithinDuration(t, start.Add(4*time.Minute), runs[3].ScheduledAt, 0)
+
+	assert.WithinDuration(t, start.Add(5*time.Minute), c.Jobs()[0].Next, 0)
+	assert.WithinDuration(t, start.Add(4*time.Minute), c.Jobs()[0].Prev, 0)
+
+	require.NoError(t, c.Shutdown(t.Context()))
+
+	clock.Advance(time.Hour)
+	assert.Len(t, runs, 4)
+}
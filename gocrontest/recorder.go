@@ -0,0 +1,121 @@
+package gocrontest
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/anticrew/gocron"
+)
+
+var _ gocron.Handler = (*Recorder)(nil)
+
+// Recorder is a gocron.Handler storing every received event; the zero value is ready to use. Pass it to gocron.WithDefaultHandler
+// or Job.WithHandler and assert on the recorded events:
+//
+//	rec := gocrontest.NewRecorder()
+//	c := gocron.NewCron(ctx, gocron.WithDefaultHandler(rec))
+//	c.MustAdd("@every 1s", cmd).WithName("export")
+//	_ = c.Start()
+//	event, ok := rec.WaitFor(gocron.StageFinish, "export", 5*time.Second)
+type Recorder struct {
+	mu     sync.Mutex
+	events []gocron.JobEvent
+	// recorded is closed and dropped on every event to wake up waiters, it's created lazily by waiters
+	recorded chan struct{}
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Handle records the event
+func (r *Recorder) Handle(event gocron.JobEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+
+	if r.recorded != nil {
+		close(r.recorded)
+		r.recorded = nil
+	}
+}
+
+// WaitFor returns the first recorded event of the job with any of the stages, waiting for it up to timeout.
+// Stages may be combined, e.g. gocron.StageExec|gocron.StagePanic; empty name matches any job.
+// The flag is false if no such event was recorded in time
+func (r *Recorder) WaitFor(stage gocron.Stage, name string, timeout time.Duration) (gocron.JobEvent, bool) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		r.mu.Lock()
+		idx := slices.IndexFunc(r.events, func(event gocron.JobEvent) bool {
+			return event.Stage&stage != 0 && matches(event, name)
+		})
+		if idx >= 0 {
+			event := r.events[idx]
+			r.mu.Unlock()
+
+			return event, true
+		}
+
+		if r.recorded == nil {
+			r.recorded = make(chan struct{})
+		}
+
+		recorded := r.recorded
+		r.mu.Unlock()
+
+		select {
+		case <-recorded:
+		case <-deadline.C:
+			return gocron.JobEvent{}, false
+		}
+	}
+}
+
+// Events returns events of the job in the order they were recorded; empty name returns events of all jobs
+func (r *Recorder) Events(name string) []gocron.JobEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []gocron.JobEvent
+	for _, event := range r.events {
+		if matches(event, name) {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// Errors returns errors of all recorded events in the order they were recorded.
+// Skip and queue reasons of StageSkip and StageQueue events are not errors and are omitted
+func (r *Recorder) Errors() []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for _, event := range r.events {
+		if event.Error != nil && event.Stage != gocron.StageSkip && event.Stage != gocron.StageQueue {
+			errs = append(errs, event.Error)
+		}
+	}
+
+	return errs
+}
+
+// Reset drops all recorded events
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = nil
+}
+
+func matches(event gocron.JobEvent, name string) bool {
+	return name == "" || event.JobName == name
+}
@@ -0,0 +1,90 @@
+package gocrontest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	rec := NewRecorder()
+	rec.Handle(gocron.JobEvent{JobName: "first", Stage: gocron.StageStart})
+	rec.Handle(gocron.JobEvent{JobName: "first", Stage: gocron.StageExec, Error: assert.AnError})
+	rec.Handle(gocron.JobEvent{JobName: "second", Stage: gocron.StageSkip, Error: gocron.ErrJobPaused})
+	rec.Handle(gocron.JobEvent{JobName: "first", Stage: gocron.StageFinish})
+
+	assert.Len(t, rec.Events(""), 4)
+	assert.Len(t, rec.Events("first"), 3)
+	assert.Empty(t, rec.Events("third"))
+	assert.Equal(t, []error{assert.AnError}, rec.Errors())
+
+	event, ok := rec.WaitFor(gocron.StageExec|gocron.StageSkip, "", 0)
+	require.True(t, ok)
+	assert.Equal(t, "first", event.JobName)
+
+	event, ok = rec.WaitFor(gocron.StageSkip, "second", 0)
+	require.True(t, ok)
+	assert.Equal(t, gocron.ErrJobPaused, event.Error)
+
+	_, ok = rec.WaitFor(gocron.StageFinish, "second", 10*time.Millisecond)
+	assert.False(t, ok)
+
+	rec.Reset()
+	assert.Empty(t, rec.Events(""))
+	assert.Empty(t, rec.Errors())
+}
+
+func TestRecorder_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var rec Recorder
+	rec.Handle(gocron.JobEvent{JobName: "first", Stage: gocron.StageStart})
+
+	found := make(chan bool, 1)
+	go func() {
+		_, ok := rec.WaitFor(gocron.StageFinish, "first", 5*time.Second)
+		found <- ok
+	}()
+
+	rec.Handle(gocron.JobEvent{JobName: "first", Stage: gocron.StageFinish})
+	assert.True(t, <-found)
+	assert.Len(t, rec.Events("first"), 2)
+}
+
+func TestRecorder_Cron(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = t.Context()
+		rec   = NewRecorder()
+		clock = NewFakeClock(time.Now())
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithDefaultHandler(rec))
+	c.MustAdd("@every 1s", func(context.Context) error { return assert.AnError }).WithName("export")
+
+	require.NoError(t, c.Start())
+
+	waited := make(chan gocron.JobEvent, 1)
+	go func() {
+		event, _ := rec.WaitFor(gocron.StageFinish, "export", 5*time.Second)
+		waited <- event
+	}()
+
+	clock.Advance(time.Second)
+
+	event := <-waited
+	assert.Equal(t, gocron.StageFinish, event.Stage)
+	assert.NotEmpty(t, event.RunID)
+
+	require.NoError(t, c.Shutdown(ctx))
+
+	require.Len(t, rec.Errors(), 1)
+	require.ErrorIs(t, rec.Errors()[0], assert.AnError)
+}
@@ -0,0 +1,12 @@
+package gocrontest
+
+import (
+	"context"
+
+	"github.com/anticrew/gocron"
+)
+
+// RunContext returns a copy of ctx holding RunInfo of the job run, e.g. to call Lock implementations in tests
+func RunContext(ctx context.Context, jobName, runID string) context.Context {
+	return gocron.WithRunInfo(ctx, gocron.RunInfo{JobName: jobName, RunID: runID})
+}
@@ -0,0 +1,55 @@
+package internal
+
+import "sync"
+
+var closed = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+
+	return ch
+}()
+
+// Group counts running tasks like sync.WaitGroup, but can be reused while previous waiters still wait
+type Group struct {
+	mu    sync.Mutex
+	count int
+	idle  chan struct{}
+}
+
+func (g *Group) Add() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.count == 0 {
+		g.idle = make(chan struct{})
+	}
+
+	g.count++
+}
+
+func (g *Group) Done() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.count == 0 {
+		panic("internal: negative Group counter")
+	}
+
+	g.count--
+
+	if g.count == 0 {
+		close(g.idle)
+	}
+}
+
+// Idle returns a channel closed once all tasks running at the moment of the call are done
+func (g *Group) Idle() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.count == 0 {
+		return closed
+	}
+
+	return g.idle
+}
@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	isClosed := func(ch <-chan struct{}) bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var g Group
+	assert.True(t, isClosed(g.Idle()))
+
+	g.Add()
+	g.Add()
+
+	first := g.Idle()
+	assert.False(t, isClosed(first))
+
+	g.Done()
+	assert.False(t, isClosed(first))
+
+	g.Done()
+	assert.True(t, isClosed(first))
+
+	g.Add()
+
+	second := g.Idle()
+	assert.False(t, isClosed(second))
+	assert.True(t, isClosed(first))
+
+	g.Done()
+	assert.True(t, isClosed(second))
+
+	assert.PanicsWithValue(t, "internal: negative Group counter", g.Done)
+}
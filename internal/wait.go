@@ -2,21 +2,13 @@ package internal
 
 import (
 	"context"
-	"sync"
 )
 
-func Wait(ctx context.Context, wg *sync.WaitGroup) error {
-	ch := make(chan struct{}, 1)
-
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
-
+func Wait(ctx context.Context, done <-chan struct{}) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-ch:
+	case <-done:
 		return nil
 	}
 }
@@ -2,7 +2,6 @@ package internal
 
 import (
 	"context"
-	"sync"
 	"testing"
 	"time"
 
@@ -24,23 +23,23 @@ func TestWait(t *testing.T) {
 	tests := []struct {
 		name           string
 		timeout        time.Duration
-		run            func(wg *sync.WaitGroup)
+		run            func(g *Group)
 		expectedFinish bool
 		expectedError  error
 	}{
 		{
 			name:    "zero deadline exceeded",
 			timeout: 0,
-			run: func(wg *sync.WaitGroup) {
-				defer wg.Done()
+			run: func(g *Group) {
+				defer g.Done()
 			},
 			expectedError: context.DeadlineExceeded,
 		},
 		{
 			name:    "timeout deadline exceeded",
 			timeout: timeoutDuration,
-			run: func(wg *sync.WaitGroup) {
-				defer wg.Done()
+			run: func(g *Group) {
+				defer g.Done()
 				time.Sleep(sleepDuration)
 			},
 			expectedError: context.DeadlineExceeded,
@@ -48,8 +47,8 @@ func TestWait(t *testing.T) {
 		{
 			name:    "wait",
 			timeout: timeoutDuration,
-			run: func(wg *sync.WaitGroup) {
-				defer wg.Done()
+			run: func(g *Group) {
+				defer g.Done()
 			},
 		},
 	}
@@ -65,14 +64,14 @@ func TestWait(t *testing.T) {
 			t.Cleanup(cancel)
 
 			var (
-				wg      = &sync.WaitGroup{}
+				g       = &Group{}
 				errChan = make(chan error, 1)
 			)
 
-			wg.Add(1)
-			go tc.run(wg)
+			g.Add()
+			go tc.run(g)
 			func() {
-				errChan <- Wait(ctx, wg)
+				errChan <- Wait(ctx, g.Idle())
 				close(errChan)
 			}()
 
@@ -2,23 +2,49 @@ package gocron
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/anticrew/gocron/internal"
+	c "github.com/robfig/cron/v3"
 )
 
 type job struct {
-	spec, name string
+	spec, name, group string
 
 	baseCtx    context.Context
 	newContext internal.ContextFactory
 
-	wg   *sync.WaitGroup
-	lock Lock
+	runs         *runSet
+	lock         Lock
+	lockTimeout  time.Duration
+	lockProvider LockProvider
+	provided     *providedLock
+
+	owner   *cron
+	entryID c.EntryID
 
 	cmd     Cmd
-	handler Handler
+	handler ContextHandler
+	tracer  Tracer
+	overlap *overlapGuard
+	retry   RetryPolicy
+
+	mu           sync.RWMutex
+	paused       atomic.Bool
+	running      atomic.Int32
+	lastErr      error
+	lastDuration time.Duration
+}
+
+// providedLock is a lock returned by LockProvider for the job name
+type providedLock struct {
+	name string
+	lock Lock
 }
 
 func newJob(baseCtx context.Context, spec string, cmd Cmd) *job {
@@ -28,31 +54,252 @@ func newJob(baseCtx context.Context, spec string, cmd Cmd) *job {
 		baseCtx:    baseCtx,
 		newContext: internal.CancelContextFactory(),
 		cmd:        cmd,
+		overlap:    newOverlapGuard(OverlapAllowConcurrent),
 	}
 }
 
 // Run executes the job command with lock and handler hooks.
 // Exported for compliance with github.com/robfig/cron's Job interface and shouldn't be called manually
 func (j *job) Run() {
-	if j.wg != nil {
-		j.wg.Add(1)
-		defer j.wg.Done()
+	j.runAt(time.Time{})
+}
+
+// runAt executes the job command for the scheduled run planned at scheduled; zero means the run start time.
+// The scheduler calls it instead of Run unless the job is wrapped with robfig's chains
+func (j *job) runAt(scheduled time.Time) {
+	ctx := j.withRunInfo(j.baseCtx, TriggerSchedule, scheduled)
+
+	if err := j.skipErr(); err != nil {
+		j.emit(ctx, JobEvent{
+			Stage: StageSkip,
+			Error: err,
+		})
+
+		return
 	}
 
-	ctx, cancel := context.WithCancel(j.baseCtx)
+	_ = j.execute(ctx)
+}
+
+// RunNow executes the job immediately outside its schedule and returns its error.
+// The run uses the same lock, timeout and handler as scheduled runs and ignores pauses
+func (j *job) RunNow(ctx context.Context) error {
+	ctx = internal.WithDefault(ctx, context.Background)
+
+	return j.execute(j.withRunInfo(ctx, TriggerManual, time.Time{}))
+}
+
+// execute runs the job with the parent context that must hold RunInfo
+func (j *job) execute(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
 
-	if !j.acquireLock(ctx) {
-		return
+	if j.runs != nil {
+		r := &activeRun{
+			info:   runInfo(ctx),
+			job:    j,
+			cancel: cancel,
+		}
+
+		j.runs.add(r)
+		defer j.runs.done(r)
+	}
+
+	onQueue := func() {
+		j.emit(ctx, JobEvent{Stage: StageQueue})
+	}
+
+	if err := j.overlap.enter(ctx, j.stopping(), cancel, onQueue); err != nil {
+		j.emit(ctx, JobEvent{
+			Stage: StageSkip,
+			Error: err,
+		})
+
+		return err
+	}
+
+	defer j.overlap.leave()
+
+	group := j.getGroup()
+	if err := j.enterLimit(ctx, group); err != nil {
+		return err
+	}
+
+	defer j.leaveLimit(group)
+
+	j.running.Add(1)
+	defer j.running.Add(-1)
+
+	start := time.Now()
+
+	runCtx, endRun := j.startRun(ctx)
+	err := j.run(runCtx)
+	endRun(err)
+
+	if !errors.Is(err, ErrLockNotAcquired) {
+		j.setResult(err, time.Since(start))
+	}
+
+	return err
+}
+
+// enterLimit applies concurrency limits of the owner cron and reports runs over the limits
+func (j *job) enterLimit(ctx context.Context, group string) error {
+	if j.owner == nil {
+		return nil
 	}
 
-	defer j.releaseLock(ctx)
+	onWait := func() {
+		j.emit(ctx, JobEvent{
+			Stage: StageQueue,
+			Error: ErrLimitReached,
+		})
+	}
+
+	err := j.owner.limiter.enter(ctx, j.stopping(), group, onWait)
+	if err == nil {
+		return nil
+	}
+
+	stage := StageSkip
+	if j.owner.limiter.policy == LimitFail && errors.Is(err, ErrLimitReached) {
+		stage = StageLimit
+		j.setResult(err, 0)
+	}
+
+	j.emit(ctx, JobEvent{
+		Stage: stage,
+		Error: err,
+	})
+
+	return err
+}
+
+func (j *job) leaveLimit(group string) {
+	if j.owner != nil {
+		j.owner.limiter.leave(group)
+	}
+}
 
-	cmdCtx, cancelCmdCtx := j.newContext(ctx)
+// run executes the lock, exec and finish stages and returns the first lock error
+// or the command error joined with the unlock error
+func (j *job) run(ctx context.Context) (err error) {
+	lock := j.getLock()
+
+	if err = j.acquireLock(ctx, lock); err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, j.releaseLock(ctx, lock))
+	}()
+
+	cmdCtx, cancelCmdCtx := j.newContext(j.lockedContext(ctx, lock))
 	defer cancelCmdCtx()
 
-	err := j.cmd(cmdCtx)
-	j.handle(StageExec, err)
+	cmdCtx, cancelCause := context.WithCancelCause(cmdCtx)
+	defer cancelCause(nil)
+
+	stopRenewal := j.renewLock(ctx, lock, cancelCause)
+	err = j.exec(cmdCtx)
+
+	return errors.Join(err, stopRenewal())
+}
+
+// renewLock refreshes the lease of RenewableLock until the returned function is called.
+// If refresh fails, the command context is cancelled with ErrLockLost and the returned function returns the error
+func (j *job) renewLock(ctx context.Context, l Lock, cancel context.CancelCauseFunc) func() error {
+	lock, ok := l.(RenewableLock)
+	if !ok || lock.RefreshInterval() <= 0 {
+		return func() error { return nil }
+	}
+
+	var (
+		renewErr error
+		stop     = make(chan struct{})
+		stopped  = make(chan struct{})
+	)
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(lock.RefreshInterval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := j.refreshLock(ctx, lock); err != nil {
+				renewErr = fmt.Errorf("%w: %w", ErrLockLost, err)
+				cancel(renewErr)
+
+				return
+			}
+		}
+	}()
+
+	return func() error {
+		close(stop)
+		<-stopped
+
+		return renewErr
+	}
+}
+
+// exec executes the command and retries it according to the retry policy. Panics are not retried
+func (j *job) exec(ctx context.Context) error {
+	trigger := runInfo(ctx).Trigger
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+
+		attemptCtx, endSpan := j.startStage(ctx, StageExec)
+		panicked, err := j.call(attemptCtx)
+		endSpan(err)
+
+		event := JobEvent{
+			Stage:    StageExec,
+			Error:    err,
+			Duration: time.Since(start),
+			Attempt:  attempt,
+			Trigger:  trigger,
+		}
+
+		if panicked {
+			event.Stage = StagePanic
+		}
+
+		if attempt > 1 {
+			event.Trigger = TriggerRetry
+		}
+
+		j.emit(ctx, event)
+
+		if panicked || !j.retry.shouldRetry(attempt, err) {
+			return err
+		}
+
+		if j.retry.wait(ctx, j.stopping(), attempt+1) != nil {
+			return err
+		}
+	}
+}
+
+// call executes the command and converts its panic into PanicError
+func (j *job) call(ctx context.Context) (panicked bool, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			panicked, err = true, &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	return false, j.cmd(ctx)
 }
 
 // WithTimeout sets the job timeout; non-positive value disables timeout
@@ -69,60 +316,354 @@ func (j *job) WithTimeout(t time.Duration) Job {
 	return j
 }
 
-// WithLock sets the lock used to guard concurrent runs.
-// Lock acquisition uses the parent context without timeout; implement lock timeouts in the Lock itself.
+// WithLock sets the lock used to guard concurrent runs instead of the default lock provider.
+// RenewableLock leases are refreshed while the command is running
 func (j *job) WithLock(lock Lock) Job {
 	j.lock = lock
 	return j
 }
 
+// WithLockTimeout sets the timeout of Lock, Unlock and RenewableLock.Refresh calls; non-positive value disables timeout
+func (j *job) WithLockTimeout(t time.Duration) Job {
+	j.lockTimeout = t
+	return j
+}
+
 // WithHandler sets the error handler used by this job; nil disabled error handling
 func (j *job) WithHandler(h Handler) Job {
-	j.handler = h
+	j.handler = AdaptHandler(h)
+	return j
+}
+
+// WithGroup sets the group of the job limited by WithGroupLimit
+func (j *job) WithGroup(group string) Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.group = group
+	return j
+}
+
+// WithOverlapPolicy sets the behavior of a run started while a previous run is still running
+func (j *job) WithOverlapPolicy(p OverlapPolicy) Job {
+	j.overlap = newOverlapGuard(p)
+	return j
+}
+
+// WithRetry sets the retry policy for failed command executions.
+// Retries run within the same lock hold and job timeout
+func (j *job) WithRetry(p RetryPolicy) Job {
+	j.retry = p
 	return j
 }
 
 // WithName sets the human-readable name used in handlers
 func (j *job) WithName(name string) Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
 	j.name = name
 	return j
 }
 
-func (j *job) withWaitGroup(wg *sync.WaitGroup) {
-	j.wg = wg
+// Remove unschedules the job from the cron it was added to
+func (j *job) Remove() error {
+	if j.owner == nil {
+		return ErrJobNotFound
+	}
+
+	return j.owner.remove(j)
+}
+
+// Reschedule replaces the job schedule with the given spec, keeping its name, lock, handler and timeout.
+// The spec is validated with the parser configured for the cron
+func (j *job) Reschedule(spec string) error {
+	if j.owner == nil {
+		return ErrJobNotFound
+	}
+
+	return j.owner.reschedule(j, spec)
+}
+
+// Pause skips scheduled runs of the job until Resume is called
+func (j *job) Pause() {
+	j.paused.Store(true)
+}
+
+// Resume continues scheduled runs of the job paused by Pause
+func (j *job) Resume() {
+	j.paused.Store(false)
+}
+
+func (j *job) withRuns(runs *runSet) {
+	j.runs = runs
+}
+
+func (j *job) withOwner(owner *cron) {
+	j.owner = owner
+}
+
+func (j *job) withLockProvider(p LockProvider) {
+	j.lockProvider = p
+}
+
+func (j *job) withTracer(t Tracer) {
+	j.tracer = t
+}
+
+// startRun starts the run span with the tracer if any
+func (j *job) startRun(ctx context.Context) (context.Context, func(err error)) {
+	if j.tracer == nil {
+		return ctx, func(error) {}
+	}
+
+	return j.tracer.StartRun(ctx)
+}
+
+// startStage starts the span of the run step with the tracer if any
+func (j *job) startStage(ctx context.Context, stage Stage) (context.Context, func(err error)) {
+	if j.tracer == nil {
+		return ctx, func(error) {}
+	}
+
+	return j.tracer.StartStage(ctx, stage)
 }
 
-func (j *job) acquireLock(ctx context.Context) bool {
-	var err error
+// getLock returns the lock set by WithLock or the lock provided for the current job name.
+// Provided locks are cached until the job is renamed
+func (j *job) getLock() Lock {
+	if j.lock != nil || j.lockProvider == nil {
+		return j.lock
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
 
-	if j.lock != nil {
-		err = j.lock.Lock(ctx)
+	if j.provided == nil || j.provided.name != j.name {
+		j.provided = &providedLock{
+			name: j.name,
+			lock: j.lockProvider(j.name),
+		}
 	}
 
-	j.handle(StageStart, err)
+	return j.provided.lock
+}
+
+func (j *job) getName() string {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
 
-	return err == nil
+	return j.name
 }
 
-func (j *job) releaseLock(ctx context.Context) {
-	var err error
+// skipErr returns the reason to skip a scheduled run or nil
+func (j *job) skipErr() error {
+	if j.paused.Load() {
+		return ErrJobPaused
+	}
+
+	if j.owner != nil && j.owner.paused.Load() {
+		return ErrCronPaused
+	}
 
-	if j.lock != nil {
-		err = j.lock.Unlock(ctx)
+	// robfig may spawn a run right before the leadership is lost
+	if j.owner != nil && j.owner.elector != nil && !j.owner.leader.Load() {
+		return ErrNotLeader
 	}
 
-	j.handle(StageFinish, err)
+	return nil
+}
+
+func (j *job) getGroup() string {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	return j.group
+}
+
+func (j *job) setSpec(spec string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.spec = spec
+}
+
+func (j *job) setResult(err error, duration time.Duration) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.lastErr = err
+	j.lastDuration = duration
+}
+
+// info returns the job state snapshot without schedule times
+func (j *job) info() JobInfo {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	return JobInfo{
+		Name:         j.name,
+		Spec:         j.spec,
+		Group:        j.group,
+		Paused:       j.paused.Load(),
+		Running:      int(j.running.Load()),
+		LastError:    j.lastErr,
+		LastDuration: j.lastDuration,
+	}
 }
 
+func (j *job) acquireLock(ctx context.Context, lock Lock) error {
+	var (
+		err   error
+		start = time.Now()
+	)
+
+	if lock != nil {
+		spanCtx, endSpan := j.startStage(ctx, StageStart)
+		lockCtx, cancel := j.lockContext(spanCtx)
+		err = lock.Lock(lockCtx)
+		cancel()
+		endSpan(err)
+	}
+
+	stage := StageStart
+	if errors.Is(err, ErrLockNotAcquired) {
+		stage = StageSkip
+	}
+
+	j.emit(ctx, JobEvent{
+		Stage:    stage,
+		Error:    err,
+		Duration: time.Since(start),
+	})
+
+	return err
+}
+
+func (j *job) refreshLock(ctx context.Context, lock RenewableLock) error {
+	start := time.Now()
+
+	spanCtx, endSpan := j.startStage(ctx, StageRefresh)
+	refreshCtx, cancel := j.lockContext(spanCtx)
+
+	err := lock.Refresh(refreshCtx)
+	cancel()
+	endSpan(err)
+
+	j.emit(ctx, JobEvent{
+		Stage:    StageRefresh,
+		Error:    err,
+		Duration: time.Since(start),
+	})
+
+	return err
+}
+
+// lockedContext returns ctx with the lock details of ContextLock
+func (j *job) lockedContext(ctx context.Context, l Lock) context.Context {
+	if lock, ok := l.(ContextLock); ok {
+		return lock.Context(ctx)
+	}
+
+	return ctx
+}
+
+// lockContext returns ctx limited by the lock timeout
+func (j *job) lockContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if j.lockTimeout > 0 {
+		return context.WithTimeout(ctx, j.lockTimeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+func (j *job) releaseLock(ctx context.Context, lock Lock) error {
+	var (
+		err   error
+		start = time.Now()
+	)
+
+	if lock != nil {
+		// the run context may be cancelled, e.g. on shutdown or replacement, but the lock must be released anyway
+		spanCtx, endSpan := j.startStage(context.WithoutCancel(ctx), StageFinish)
+		unlockCtx, cancel := j.lockContext(spanCtx)
+		err = lock.Unlock(unlockCtx)
+		cancel()
+		endSpan(err)
+	}
+
+	j.emit(ctx, JobEvent{
+		Stage:    StageFinish,
+		Error:    err,
+		Duration: time.Since(start),
+	})
+
+	return err
+}
+
+// now returns the current time of the owner cron clock
+func (j *job) now() time.Time {
+	if j.owner == nil {
+		return time.Now()
+	}
+
+	return j.owner.clock.Now()
+}
+
+// stopping returns a channel closed once the owner cron is shut down
+func (j *job) stopping() <-chan struct{} {
+	if j.owner == nil {
+		return nil
+	}
+
+	return j.owner.stopping()
+}
+
+// handle passes an event not related to a particular run to the handler
 func (j *job) handle(stage Stage, err error) {
+	j.emit(j.baseCtx, JobEvent{
+		Stage: stage,
+		Error: err,
+	})
+}
+
+// emit fills the job details and the run details from ctx and passes the event to the handler
+func (j *job) emit(ctx context.Context, event JobEvent) {
 	if j.handler == nil {
 		return
 	}
 
-	j.handler.Handle(JobEvent{
-		JobSpec: j.spec,
-		JobName: j.name,
-		Stage:   stage,
-		Error:   err,
+	j.mu.RLock()
+	event.JobSpec, event.JobName = j.spec, j.name
+	j.mu.RUnlock()
+
+	if info, ok := RunInfoFromContext(ctx); ok {
+		event.RunID = info.RunID
+		event.ScheduledAt = info.ScheduledAt
+		event.StartedAt = info.StartedAt
+
+		if event.Trigger == 0 {
+			event.Trigger = info.Trigger
+		}
+	}
+
+	j.handler.HandleContext(ctx, event)
+}
+
+// withRunInfo returns ctx holding RunInfo of a new run started now; zero scheduled time means now
+func (j *job) withRunInfo(ctx context.Context, trigger Trigger, scheduled time.Time) context.Context {
+	now := j.now()
+
+	j.mu.RLock()
+	spec, name := j.spec, j.name
+	j.mu.RUnlock()
+
+	return WithRunInfo(ctx, RunInfo{
+		JobSpec:     spec,
+		JobName:     name,
+		RunID:       internal.RandName(runIDSize),
+		ScheduledAt: internal.WithDefault(scheduled, func() time.Time { return now }),
+		StartedAt:   now,
+		Trigger:     trigger,
 	})
 }
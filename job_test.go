@@ -2,11 +2,14 @@ package gocron
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type jobLock struct {
@@ -22,14 +25,55 @@ func (l *jobLock) Unlock(context.Context) error {
 	return l.unlockErr
 }
 
+type countingLock struct {
+	unlocked int
+}
+
+func (l *countingLock) Lock(context.Context) error {
+	return nil
+}
+
+func (l *countingLock) Unlock(context.Context) error {
+	l.unlocked++
+	return nil
+}
+
 type jobHandler struct {
+	mu     sync.Mutex
 	events []JobEvent
 }
 
 func (h *jobHandler) Handle(event JobEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	h.events = append(h.events, event)
 }
 
+// withoutRun checks that all events belong to the same run and clears the run details
+func withoutRun(t *testing.T, events []JobEvent) []JobEvent {
+	t.Helper()
+
+	stripped := make([]JobEvent, 0, len(events))
+	for _, event := range events {
+		assert.Equal(t, events[0].RunID, event.RunID)
+		assert.Len(t, event.RunID, runIDSize)
+		assert.NotZero(t, event.ScheduledAt)
+		assert.NotZero(t, event.StartedAt)
+		assert.Equal(t, TriggerSchedule, event.Trigger)
+
+		event.RunID = ""
+		event.ScheduledAt = time.Time{}
+		event.StartedAt = time.Time{}
+		event.Duration = 0
+		event.Trigger = 0
+
+		stripped = append(stripped, event)
+	}
+
+	return stripped
+}
+
 func TestJob_Timeout(t *testing.T) {
 	t.Parallel()
 
@@ -130,6 +174,21 @@ func TestJob_Handler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "lock held elsewhere",
+			err:  nil,
+			lock: &jobLock{
+				lockErr: fmt.Errorf("redis: %w", ErrLockNotAcquired),
+			},
+			expected: []JobEvent{
+				{
+					JobSpec: spec,
+					JobName: name,
+					Stage:   StageSkip,
+					Error:   fmt.Errorf("redis: %w", ErrLockNotAcquired),
+				},
+			},
+		},
 		{
 			name: "start execute",
 			err:  assert.AnError,
@@ -145,6 +204,7 @@ func TestJob_Handler(t *testing.T) {
 					JobName: name,
 					Stage:   StageExec,
 					Error:   assert.AnError,
+					Attempt: 1,
 				},
 				{
 					JobSpec: spec,
@@ -172,6 +232,7 @@ func TestJob_Handler(t *testing.T) {
 					JobName: name,
 					Stage:   StageExec,
 					Error:   nil,
+					Attempt: 1,
 				},
 				{
 					JobSpec: spec,
@@ -200,7 +261,7 @@ func TestJob_Handler(t *testing.T) {
 
 			j.Run()
 
-			assert.Equal(t, tc.expected, events)
+			assert.Equal(t, tc.expected, withoutRun(t, events))
 		})
 	}
 
@@ -218,7 +279,7 @@ func TestJob_Handler(t *testing.T) {
 	})
 }
 
-func TestJob_WaitGroup(t *testing.T) {
+func TestJob_Runs(t *testing.T) {
 	t.Parallel()
 
 	const (
@@ -231,14 +292,557 @@ func TestJob_WaitGroup(t *testing.T) {
 		return nil
 	})
 
-	wg := &sync.WaitGroup{}
-	j.withWaitGroup(wg)
+	runs := newRunSet()
+	j.withRuns(runs)
 
 	start := time.Now()
 	go j.Run()
 
 	time.Sleep(spread)
-	wg.Wait()
+	<-runs.idle()
 
 	assert.GreaterOrEqual(t, time.Since(start), sleep-spread)
 }
+
+func TestJob_Remove(t *testing.T) {
+	t.Parallel()
+
+	j := newJob(t.Context(), "spec", func(context.Context) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, j.Remove(), ErrJobNotFound)
+}
+
+func TestJob_Info(t *testing.T) {
+	t.Parallel()
+
+	j := newJob(t.Context(), "spec", func(context.Context) error {
+		return assert.AnError
+	})
+	j.WithName("name")
+	j.WithLock(&jobLock{unlockErr: context.Canceled})
+
+	assert.Equal(t, JobInfo{Name: "name", Spec: "spec"}, j.info())
+
+	j.Run()
+
+	info := j.info()
+	assert.Equal(t, "name", info.Name)
+	assert.Zero(t, info.Running)
+	assert.ErrorIs(t, info.LastError, assert.AnError)
+	assert.ErrorIs(t, info.LastError, context.Canceled)
+	assert.Positive(t, info.LastDuration)
+}
+
+func TestJob_RunNow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		lock     Lock
+		timeout  time.Duration
+		job      time.Duration
+		expected []error
+		stages   []Stage
+	}{
+		{
+			name:     "success",
+			expected: nil,
+			stages:   []Stage{StageStart, StageExec, StageFinish},
+		},
+		{
+			name:     "command error",
+			err:      assert.AnError,
+			expected: []error{assert.AnError},
+			stages:   []Stage{StageStart, StageExec, StageFinish},
+		},
+		{
+			name:     "lock error",
+			lock:     &jobLock{lockErr: assert.AnError},
+			expected: []error{assert.AnError},
+			stages:   []Stage{StageStart},
+		},
+		{
+			name:     "unlock error",
+			lock:     &jobLock{unlockErr: assert.AnError},
+			expected: []error{assert.AnError},
+			stages:   []Stage{StageStart, StageExec, StageFinish},
+		},
+		{
+			name:     "timeout",
+			timeout:  5 * time.Millisecond,
+			job:      time.Second,
+			expected: []error{context.DeadlineExceeded},
+			stages:   []Stage{StageStart, StageExec, StageFinish},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			j := newJob(t.Context(), "spec", func(ctx context.Context) error {
+				if tc.job > 0 {
+					select {
+					case <-time.After(tc.job):
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+
+				return tc.err
+			})
+
+			var stages []Stage
+			j.WithTimeout(tc.timeout).
+				WithLock(tc.lock).
+				WithHandler(HandlerFunc(func(event JobEvent) {
+					stages = append(stages, event.Stage)
+				}))
+
+			err := j.RunNow(t.Context())
+
+			if len(tc.expected) == 0 {
+				assert.NoError(t, err)
+			}
+
+			for _, expected := range tc.expected {
+				assert.ErrorIs(t, err, expected)
+			}
+
+			assert.Equal(t, tc.stages, stages)
+		})
+	}
+}
+
+func TestJob_Pause(t *testing.T) {
+	t.Parallel()
+
+	var (
+		called int
+		events []JobEvent
+	)
+
+	j := newJob(t.Context(), "spec", func(context.Context) error {
+		called++
+		return nil
+	})
+	j.WithName("name").
+		WithHandler(HandlerFunc(func(event JobEvent) {
+			if event.Stage == StageSkip {
+				events = append(events, event)
+			}
+		}))
+
+	j.Pause()
+	assert.True(t, j.info().Paused)
+
+	j.Run()
+	assert.Zero(t, called)
+
+	assert.NoError(t, j.RunNow(t.Context()))
+	assert.Equal(t, 1, called)
+
+	j.Resume()
+	j.Run()
+	assert.Equal(t, 2, called)
+
+	assert.Equal(t, []JobEvent{
+		{
+			JobSpec: "spec",
+			JobName: "name",
+			Stage:   StageSkip,
+			Error:   ErrJobPaused,
+		},
+	}, withoutRun(t, events))
+}
+
+func TestJob_Reschedule(t *testing.T) {
+	t.Parallel()
+
+	j := newJob(t.Context(), "spec", func(context.Context) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, j.Reschedule("@every 1s"), ErrJobNotFound)
+}
+
+func TestJob_Panic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     any
+		unwrapped error
+	}{
+		{
+			name:  "value",
+			value: "boom",
+		},
+		{
+			name:      "error",
+			value:     assert.AnError,
+			unwrapped: assert.AnError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			j := newJob(t.Context(), "spec", func(context.Context) error {
+				calls++
+				panic(tc.value)
+			})
+
+			lock := &countingLock{}
+
+			var stages []Stage
+			j.WithLock(lock).
+				WithRetry(RetryPolicy{MaxAttempts: 3}).
+				WithHandler(HandlerFunc(func(event JobEvent) {
+					stages = append(stages, event.Stage)
+				}))
+
+			var err error
+			assert.NotPanics(t, func() {
+				err = j.RunNow(t.Context())
+			})
+
+			var panicErr *PanicError
+			require.ErrorAs(t, err, &panicErr)
+			assert.Equal(t, tc.value, panicErr.Value)
+			assert.Contains(t, string(panicErr.Stack), "TestJob_Panic")
+
+			if tc.unwrapped != nil {
+				assert.ErrorIs(t, err, tc.unwrapped)
+			}
+
+			assert.Equal(t, 1, calls)
+			assert.Equal(t, []Stage{StageStart, StagePanic, StageFinish}, stages)
+			assert.Equal(t, 1, lock.unlocked)
+		})
+	}
+}
+
+func TestJob_RunDetails(t *testing.T) {
+	t.Parallel()
+
+	const sleep = 10 * time.Millisecond
+
+	var calls int
+	j := newJob(t.Context(), "spec", func(context.Context) error {
+		calls++
+		time.Sleep(sleep)
+
+		if calls == 1 {
+			return assert.AnError
+		}
+
+		return nil
+	})
+
+	var events []JobEvent
+	j.WithRetry(RetryPolicy{MaxAttempts: 2}).
+		WithHandler(HandlerFunc(func(event JobEvent) {
+			events = append(events, event)
+		}))
+
+	require.NoError(t, j.RunNow(t.Context()))
+	require.Len(t, events, 4)
+
+	var (
+		start = events[0]
+		first = events[1]
+		retry = events[2]
+	)
+
+	assert.Equal(t, StageStart, start.Stage)
+	assert.Equal(t, TriggerManual, start.Trigger)
+	assert.Equal(t, start.StartedAt, start.ScheduledAt)
+
+	assert.Equal(t, StageExec, first.Stage)
+	assert.Equal(t, 1, first.Attempt)
+	assert.Equal(t, TriggerManual, first.Trigger)
+	assert.GreaterOrEqual(t, first.Duration, sleep)
+
+	assert.Equal(t, StageExec, retry.Stage)
+	assert.Equal(t, 2, retry.Attempt)
+	assert.Equal(t, TriggerRetry, retry.Trigger)
+	assert.GreaterOrEqual(t, retry.Duration, sleep)
+
+	for _, event := range events {
+		assert.Equal(t, start.RunID, event.RunID)
+		assert.Equal(t, start.StartedAt, event.StartedAt)
+	}
+
+	require.NoError(t, j.RunNow(t.Context()))
+	require.Len(t, events, 7)
+	assert.NotEqual(t, start.RunID, events[4].RunID)
+}
+
+func TestJob_LockNotAcquired(t *testing.T) {
+	t.Parallel()
+
+	var called bool
+	j := newJob(t.Context(), "spec", func(context.Context) error {
+		called = true
+		return nil
+	})
+	j.WithLock(&jobLock{lockErr: ErrLockNotAcquired})
+
+	require.ErrorIs(t, j.RunNow(t.Context()), ErrLockNotAcquired)
+	assert.False(t, called)
+	assert.NoError(t, j.info().LastError)
+}
+
+type blockingLock struct{}
+
+func (l *blockingLock) Lock(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (l *blockingLock) Unlock(context.Context) error {
+	return nil
+}
+
+// ctxLock fails Lock and Unlock calls with a done context like network-based locks do
+type ctxLock struct {
+	mu   sync.Mutex
+	held bool
+}
+
+func (l *ctxLock) Lock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held {
+		return ErrLockNotAcquired
+	}
+
+	l.held = true
+	return nil
+}
+
+func (l *ctxLock) Unlock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.held = false
+	return nil
+}
+
+func (l *ctxLock) isHeld() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.held
+}
+
+type renewableLock struct {
+	countingLock
+
+	interval   time.Duration
+	refreshErr error
+	refreshed  atomic.Int32
+}
+
+func (l *renewableLock) Refresh(context.Context) error {
+	l.refreshed.Add(1)
+	return l.refreshErr
+}
+
+func (l *renewableLock) RefreshInterval() time.Duration {
+	return l.interval
+}
+
+func TestJob_LockTimeout(t *testing.T) {
+	t.Parallel()
+
+	var called bool
+	j := newJob(t.Context(), "spec", func(context.Context) error {
+		called = true
+		return nil
+	})
+	j.WithLock(&blockingLock{}).WithLockTimeout(10 * time.Millisecond)
+
+	require.ErrorIs(t, j.RunNow(t.Context()), context.DeadlineExceeded)
+	assert.False(t, called)
+}
+
+func TestJob_UnlockCancelledRun(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	lock := &ctxLock{}
+	j := newJob(t.Context(), "spec", func(context.Context) error {
+		cancel()
+		return nil
+	})
+	j.WithLock(lock).WithLockTimeout(time.Second)
+
+	require.NoError(t, j.RunNow(ctx))
+	assert.False(t, lock.isHeld(), "the lock is released after the run is cancelled")
+}
+
+func TestJob_RenewableLock(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		refreshErr    error
+		expectedError error
+	}{
+		{
+			name: "refreshes lease while running",
+		},
+		{
+			name:          "cancels command when lease is lost",
+			refreshErr:    assert.AnError,
+			expectedError: ErrLockLost,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lock    = &renewableLock{interval: 10 * time.Millisecond, refreshErr: tc.refreshErr}
+				handler = &jobHandler{}
+				cause   error
+			)
+
+			j := newJob(t.Context(), "spec", func(ctx context.Context) error {
+				select {
+				case <-ctx.Done():
+					cause = context.Cause(ctx)
+					return ctx.Err()
+				case <-time.After(100 * time.Millisecond):
+					return nil
+				}
+			})
+			j.WithLock(lock).WithHandler(handler)
+
+			err := j.RunNow(t.Context())
+			if tc.expectedError == nil {
+				require.NoError(t, err)
+				assert.NoError(t, cause)
+				assert.Greater(t, lock.refreshed.Load(), int32(1))
+			} else {
+				require.ErrorIs(t, err, tc.expectedError)
+				require.ErrorIs(t, err, tc.refreshErr)
+				assert.ErrorIs(t, cause, tc.expectedError)
+				assert.Equal(t, int32(1), lock.refreshed.Load())
+			}
+
+			assert.Equal(t, 1, lock.unlocked)
+
+			var refreshed int
+			for _, event := range handler.events {
+				if event.Stage == StageRefresh {
+					refreshed++
+					assert.Equal(t, tc.refreshErr, event.Error)
+				}
+			}
+			assert.Equal(t, int(lock.refreshed.Load()), refreshed)
+		})
+	}
+}
+
+type contextLockKey struct{}
+
+type contextLock struct {
+	countingLock
+}
+
+func (l *contextLock) Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextLockKey{}, "token")
+}
+
+func TestJob_ContextLock(t *testing.T) {
+	t.Parallel()
+
+	var value any
+	j := newJob(t.Context(), "spec", func(ctx context.Context) error {
+		value = ctx.Value(contextLockKey{})
+		return nil
+	})
+	j.WithLock(&contextLock{})
+
+	require.NoError(t, j.RunNow(t.Context()))
+	assert.Equal(t, "token", value)
+}
+
+type spanKey struct{}
+
+// spanTracer records started and ended spans, passing the innermost span name in the context
+type spanTracer struct {
+	mu    sync.Mutex
+	spans []string
+}
+
+func (tr *spanTracer) StartRun(ctx context.Context) (context.Context, func(err error)) {
+	return tr.start(ctx, "run")
+}
+
+func (tr *spanTracer) StartStage(ctx context.Context, stage Stage) (context.Context, func(err error)) {
+	return tr.start(ctx, stage.String())
+}
+
+func (tr *spanTracer) start(ctx context.Context, name string) (context.Context, func(err error)) {
+	tr.record("start " + name)
+
+	return context.WithValue(ctx, spanKey{}, name), func(err error) {
+		tr.record(fmt.Sprintf("end %s: %v", name, err))
+	}
+}
+
+func (tr *spanTracer) record(span string) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.spans = append(tr.spans, span)
+}
+
+func TestJob_Tracer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tracer = &spanTracer{}
+		span   any
+	)
+
+	j := newJob(t.Context(), "spec", func(ctx context.Context) error {
+		span = ctx.Value(spanKey{})
+		return assert.AnError
+	})
+	j.WithLock(&jobLock{})
+	j.withTracer(tracer)
+
+	require.ErrorIs(t, j.RunNow(t.Context()), assert.AnError)
+	assert.Equal(t, "exec", span)
+	assert.Equal(t, []string{
+		"start run",
+		"start start",
+		"end start: <nil>",
+		"start exec",
+		"end exec: " + assert.AnError.Error(),
+		"start finish",
+		"end finish: <nil>",
+		"end run: " + assert.AnError.Error(),
+	}, tracer.spans)
+}
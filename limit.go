@@ -0,0 +1,146 @@
+package gocron
+
+import (
+	"context"
+	"fmt"
+)
+
+// limits configures concurrency limits of a cron
+type limits struct {
+	policy LimitPolicy
+	max    int
+	groups map[string]int
+}
+
+// WithMaxConcurrentRuns limits the number of concurrently running jobs of the cron; non-positive value disables the limit.
+// Runs over the limit are handled according to WithLimitPolicy
+func WithMaxConcurrentRuns(n int) Option {
+	return func(o *optionsHolder) {
+		o.limits.max = n
+	}
+}
+
+// WithGroupLimit limits the number of concurrently running jobs of the group set by Job.WithGroup.
+// Non-positive value disables the limit. Runs over the limit are handled according to WithLimitPolicy
+func WithGroupLimit(group string, n int) Option {
+	return func(o *optionsHolder) {
+		if o.limits.groups == nil {
+			o.limits.groups = make(map[string]int)
+		}
+
+		o.limits.groups[group] = n
+	}
+}
+
+// WithLimitPolicy sets the behavior of a run started while a concurrency limit is reached; LimitWait is used by default
+func WithLimitPolicy(p LimitPolicy) Option {
+	return func(o *optionsHolder) {
+		o.limits.policy = p
+	}
+}
+
+// limiter applies concurrency limits to runs of all jobs of a cron
+type limiter struct {
+	policy LimitPolicy
+	global chan struct{}
+	groups map[string]chan struct{}
+}
+
+func newLimiter(l limits) *limiter {
+	lim := &limiter{
+		policy: l.policy,
+		groups: make(map[string]chan struct{}, len(l.groups)),
+	}
+
+	if l.max > 0 {
+		lim.global = make(chan struct{}, l.max)
+	}
+
+	for group, n := range l.groups {
+		if n > 0 {
+			lim.groups[group] = make(chan struct{}, n)
+		}
+	}
+
+	return lim
+}
+
+// enter takes a slot of the group limit and then of the global limit according to the policy.
+// It returns ErrLimitReached if the run should be skipped or failed, ctx error if ctx is done while waiting,
+// ErrCronStopping if stop is closed while waiting, and calls onWait before waiting
+func (l *limiter) enter(ctx context.Context, stop <-chan struct{}, group string, onWait func()) error {
+	var (
+		slots    = l.slots(group)
+		acquired = make([]chan struct{}, 0, len(slots))
+		waiting  bool
+	)
+
+	for _, slot := range slots {
+		err := l.acquire(ctx, stop, slot, func() {
+			if !waiting {
+				waiting = true
+				onWait()
+			}
+		})
+		if err != nil {
+			release(acquired)
+			return err
+		}
+
+		acquired = append(acquired, slot)
+	}
+
+	return nil
+}
+
+// leave releases the slots taken by enter
+func (l *limiter) leave(group string) {
+	release(l.slots(group))
+}
+
+// slots returns the slots of the limits applied to the group
+func (l *limiter) slots(group string) []chan struct{} {
+	var slots []chan struct{}
+
+	if slot, ok := l.groups[group]; ok {
+		slots = append(slots, slot)
+	}
+
+	if l.global != nil {
+		slots = append(slots, l.global)
+	}
+
+	return slots
+}
+
+func (l *limiter) acquire(ctx context.Context, stop <-chan struct{}, slot chan struct{}, onWait func()) error {
+	select {
+	case slot <- struct{}{}:
+		return nil
+	default:
+	}
+
+	switch l.policy {
+	case LimitSkip, LimitFail:
+		return fmt.Errorf("%w: %d runs", ErrLimitReached, cap(slot))
+
+	case LimitWait:
+	}
+
+	onWait()
+
+	select {
+	case slot <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-stop:
+		return ErrCronStopping
+	}
+}
+
+func release(slots []chan struct{}) {
+	for _, slot := range slots {
+		<-slot
+	}
+}
@@ -0,0 +1,285 @@
+package gocron
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		limits        limits
+		group         string
+		stopped       bool
+		expectedError error
+		expectedWait  bool
+	}{
+		{
+			name:   "passes without limits",
+			limits: limits{policy: LimitFail},
+			group:  "db",
+		},
+		{
+			name:          "fails over global limit",
+			limits:        limits{policy: LimitFail, max: 1},
+			expectedError: ErrLimitReached,
+		},
+		{
+			name:          "skips over group limit",
+			limits:        limits{policy: LimitSkip, groups: map[string]int{"db": 1}},
+			group:         "db",
+			expectedError: ErrLimitReached,
+		},
+		{
+			name:   "ignores limits of other groups",
+			limits: limits{policy: LimitFail, groups: map[string]int{"db": 1}},
+			group:  "api",
+		},
+		{
+			name:          "waits over limit",
+			limits:        limits{policy: LimitWait, max: 1},
+			expectedError: context.DeadlineExceeded,
+			expectedWait:  true,
+		},
+		{
+			name:          "stops waiting on shutdown",
+			limits:        limits{policy: LimitWait, groups: map[string]int{"db": 1}},
+			group:         "db",
+			stopped:       true,
+			expectedError: ErrCronStopping,
+			expectedWait:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := newLimiter(tc.limits)
+			require.NoError(t, l.enter(t.Context(), nil, tc.group, func() {}))
+
+			ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+			defer cancel()
+
+			stop := make(chan struct{})
+			if tc.stopped {
+				close(stop)
+			}
+
+			var waited bool
+			err := l.enter(ctx, stop, tc.group, func() { waited = true })
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedWait, waited)
+
+			if err == nil {
+				l.leave(tc.group)
+			}
+
+			l.leave(tc.group)
+			require.NoError(t, l.enter(t.Context(), nil, tc.group, func() {}), "slots are released")
+		})
+	}
+}
+
+func TestLimiter_Release(t *testing.T) {
+	t.Parallel()
+
+	l := newLimiter(limits{policy: LimitSkip, max: 1, groups: map[string]int{"db": 2}})
+
+	require.NoError(t, l.enter(t.Context(), nil, "", func() {}))
+	require.ErrorIs(t, l.enter(t.Context(), nil, "db", func() {}), ErrLimitReached)
+
+	l.leave("")
+
+	require.NoError(t, l.enter(t.Context(), nil, "db", func() {}), "group slot is released after global limit failure")
+	l.leave("db")
+	assert.Empty(t, l.groups["db"])
+}
+
+func TestCron_MaxConcurrentRuns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		policy        LimitPolicy
+		group         string
+		options       []Option
+		expectedStage Stage
+		expectedError error
+	}{
+		{
+			name:          "waits for global limit",
+			policy:        LimitWait,
+			options:       []Option{WithMaxConcurrentRuns(1)},
+			expectedStage: StageQueue,
+		},
+		{
+			name:          "skips over global limit",
+			policy:        LimitSkip,
+			options:       []Option{WithMaxConcurrentRuns(1)},
+			expectedStage: StageSkip,
+			expectedError: ErrLimitReached,
+		},
+		{
+			name:          "fails over group limit",
+			policy:        LimitFail,
+			group:         "db",
+			options:       []Option{WithGroupLimit("db", 1)},
+			expectedStage: StageLimit,
+			expectedError: ErrLimitReached,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu      sync.Mutex
+				events  []JobEvent
+				started = make(chan struct{})
+				release = make(chan struct{})
+			)
+
+			options := append([]Option{
+				WithLimitPolicy(tc.policy),
+				WithDefaultHandler(HandlerFunc(func(event JobEvent) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					events = append(events, event)
+				})),
+			}, tc.options...)
+
+			c := NewCron(t.Context(), options...)
+
+			blocking := c.MustAdd("@every 1h", func(context.Context) error {
+				close(started)
+				<-release
+
+				return nil
+			}).WithName("blocking").WithGroup(tc.group)
+
+			limited := c.MustAdd("@every 1h", func(context.Context) error {
+				return nil
+			}).WithName("limited").WithGroup(tc.group)
+
+			unlimited := c.MustAdd("@every 1h", func(context.Context) error {
+				return nil
+			}).WithName("unlimited").WithGroup("other")
+
+			done := make(chan error, 1)
+			go func() {
+				done <- blocking.RunNow(t.Context())
+			}()
+			<-started
+
+			if tc.policy == LimitWait {
+				time.AfterFunc(10*time.Millisecond, func() { close(release) })
+			}
+
+			require.ErrorIs(t, limited.RunNow(t.Context()), tc.expectedError)
+
+			if tc.group != "" {
+				require.NoError(t, unlimited.RunNow(t.Context()))
+			}
+
+			if tc.policy != LimitWait {
+				close(release)
+			}
+
+			require.NoError(t, <-done)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			var limitedEvents []JobEvent
+			for _, event := range events {
+				if event.JobName == "limited" {
+					limitedEvents = append(limitedEvents, event)
+				}
+			}
+
+			require.NotEmpty(t, limitedEvents)
+			assert.Equal(t, tc.expectedStage, limitedEvents[0].Stage)
+			require.ErrorIs(t, limitedEvents[0].Error, ErrLimitReached)
+
+			if tc.expectedStage == StageLimit {
+				jb, ok := limited.(*job)
+				require.True(t, ok)
+				assert.ErrorIs(t, jb.info().LastError, ErrLimitReached)
+			}
+		})
+	}
+}
+
+func TestCron_LimitWaitShutdown(t *testing.T) {
+	t.Parallel()
+
+	var (
+		queued  = make(chan struct{})
+		skipped = make(chan JobEvent, 1)
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	c := NewCron(t.Context(), WithMaxConcurrentRuns(1), WithDefaultHandler(HandlerFunc(func(event JobEvent) {
+		if event.JobName != "limited" {
+			return
+		}
+
+		switch event.Stage {
+		case StageQueue:
+			close(queued)
+		case StageSkip:
+			skipped <- event
+		default:
+		}
+	})))
+
+	blocking := c.MustAdd("@yearly", func(context.Context) error {
+		close(started)
+		<-release
+
+		return nil
+	}).WithName("blocking")
+
+	limited := c.MustAdd("@yearly", func(context.Context) error {
+		return nil
+	}).WithName("limited")
+
+	require.NoError(t, c.Start())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- blocking.RunNow(t.Context())
+	}()
+	<-started
+
+	waiting := make(chan error, 1)
+	go func() {
+		waiting <- limited.RunNow(t.Context())
+	}()
+	<-queued
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- c.Shutdown(t.Context())
+	}()
+
+	require.ErrorIs(t, <-waiting, ErrCronStopping, "waiting runs don't block shutdown")
+
+	event := <-skipped
+	require.ErrorIs(t, event.Error, ErrCronStopping)
+
+	close(release)
+	require.NoError(t, <-done)
+	require.NoError(t, <-shutdown)
+}
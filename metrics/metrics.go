@@ -0,0 +1,261 @@
+// Package metrics provides a gocron handler collecting job metrics
+// and serving them in the Prometheus text exposition format
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anticrew/gocron"
+)
+
+// DefaultNamespace prefixes metric names unless WithNamespace is used
+const DefaultNamespace = "gocron"
+
+// jobLabel is the label holding the job name; "job" is reserved for the Prometheus scrape target
+const jobLabel = "job_name"
+
+// contentType is the content type of the Prometheus text exposition format
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are upper bounds in seconds of the command duration histogram unless WithBuckets is used
+var DefaultBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 3600}
+
+var (
+	_ gocron.Handler = (*Handler)(nil)
+	_ http.Handler   = (*Handler)(nil)
+)
+
+// Handler is a gocron.Handler maintaining per-job metrics and an http.Handler serving them:
+//   - <namespace>_job_runs_total counts started runs;
+//   - <namespace>_job_failures_total counts errors by stage, e.g. exec, panic or finish;
+//   - <namespace>_job_skips_total counts skipped runs;
+//   - <namespace>_job_duration_seconds is a histogram of command execution durations, retry attempts included;
+//   - <namespace>_job_last_success_timestamp_seconds is the unix time of the last successful command execution.
+//
+// All metrics are labeled with the job name in the job_name label. Series of a job are dropped once it's removed
+type Handler struct {
+	namespace string
+	buckets   []float64
+	now       func() time.Time
+
+	mu   sync.Mutex
+	jobs map[string]*jobMetrics
+}
+
+type jobMetrics struct {
+	runs        uint64
+	skips       uint64
+	failures    map[gocron.Stage]uint64
+	duration    histogram
+	lastSuccess time.Time
+}
+
+type histogram struct {
+	// counts holds the number of observations per bucket, the last one is +Inf
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHandler creates a handler with DefaultNamespace and DefaultBuckets
+func NewHandler() *Handler {
+	return &Handler{
+		namespace: DefaultNamespace,
+		buckets:   DefaultBuckets,
+		now:       time.Now,
+		jobs:      make(map[string]*jobMetrics),
+	}
+}
+
+// WithNamespace sets the metric name prefix; empty namespace means no prefix.
+// Must be called before the handler receives events
+func (h *Handler) WithNamespace(namespace string) *Handler {
+	h.namespace = namespace
+	return h
+}
+
+// WithBuckets sets upper bounds in seconds of the duration histogram; empty buckets mean DefaultBuckets.
+// Must be called before the handler receives events
+func (h *Handler) WithBuckets(buckets ...float64) *Handler {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	h.buckets = slices.Sorted(slices.Values(buckets))
+	return h
+}
+
+// Handle updates metrics of the event job
+func (h *Handler) Handle(event gocron.JobEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if event.Stage == gocron.StageRemove {
+		delete(h.jobs, event.JobName)
+		return
+	}
+
+	m := h.job(event.JobName)
+
+	switch {
+	case event.Stage == gocron.StageSkip:
+		m.skips++
+
+	case event.Stage == gocron.StageQueue:
+
+	case event.Error != nil:
+		m.failures[event.Stage]++
+
+	case event.Stage == gocron.StageStart:
+		m.runs++
+
+	case event.Stage == gocron.StageExec:
+		m.lastSuccess = h.now()
+	}
+
+	if event.Stage == gocron.StageExec || event.Stage == gocron.StagePanic {
+		m.duration.observe(h.buckets, event.Duration)
+	}
+}
+
+// ServeHTTP writes all metrics in the Prometheus text exposition format
+func (h *Handler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	var buf bytes.Buffer
+	h.write(&buf)
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = buf.WriteTo(w)
+}
+
+func (h *Handler) job(name string) *jobMetrics {
+	m, ok := h.jobs[name]
+	if !ok {
+		m = &jobMetrics{
+			failures: make(map[gocron.Stage]uint64),
+			duration: histogram{counts: make([]uint64, len(h.buckets)+1)},
+		}
+		h.jobs[name] = m
+	}
+
+	return m
+}
+
+// write writes metric families sorted by job name
+func (h *Handler) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	names := slices.Sorted(maps.Keys(h.jobs))
+
+	runs := h.family(w, "job_runs_total", "counter", "Number of started job runs.")
+	for _, name := range names {
+		runs.sample("", float64(h.jobs[name].runs), jobLabel, name)
+	}
+
+	failures := h.family(w, "job_failures_total", "counter", "Number of job errors by stage.")
+	for _, name := range names {
+		m := h.jobs[name]
+		for _, stage := range slices.Sorted(maps.Keys(m.failures)) {
+			failures.sample("", float64(m.failures[stage]), jobLabel, name, "stage", stage.String())
+		}
+	}
+
+	skips := h.family(w, "job_skips_total", "counter", "Number of skipped job runs.")
+	for _, name := range names {
+		skips.sample("", float64(h.jobs[name].skips), jobLabel, name)
+	}
+
+	duration := h.family(w, "job_duration_seconds", "histogram", "Duration of job command executions.")
+	for _, name := range names {
+		hist := h.jobs[name].duration
+
+		var cumulative uint64
+		for i, count := range hist.counts {
+			cumulative += count
+
+			le := "+Inf"
+			if i < len(h.buckets) {
+				le = formatFloat(h.buckets[i])
+			}
+
+			duration.sample("_bucket", float64(cumulative), jobLabel, name, "le", le)
+		}
+
+		duration.sample("_sum", hist.sum, jobLabel, name)
+		duration.sample("_count", float64(hist.count), jobLabel, name)
+	}
+
+	lastSuccess := h.family(w, "job_last_success_timestamp_seconds", "gauge",
+		"Unix time of the last successful job command execution.")
+	for _, name := range names {
+		if t := h.jobs[name].lastSuccess; !t.IsZero() {
+			lastSuccess.sample("", float64(t.UnixNano())/float64(time.Second), jobLabel, name)
+		}
+	}
+}
+
+// family writes HELP and TYPE lines of a metric family and returns a writer of its samples
+func (h *Handler) family(w io.Writer, name, typ, help string) family {
+	if h.namespace != "" {
+		name = h.namespace + "_" + name
+	}
+
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+
+	return family{w: w, name: name}
+}
+
+type family struct {
+	w    io.Writer
+	name string
+}
+
+// sample writes a sample of the family with name suffix and label name-value pairs
+func (f family) sample(suffix string, value float64, labels ...string) {
+	var b strings.Builder
+
+	b.WriteString(f.name)
+	b.WriteString(suffix)
+	b.WriteByte('{')
+
+	for i := 0; i < len(labels); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteString(labels[i])
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(labels[i+1]))
+		b.WriteByte('"')
+	}
+
+	b.WriteString("} ")
+	b.WriteString(formatFloat(value))
+	b.WriteByte('\n')
+
+	_, _ = io.WriteString(f.w, b.String())
+}
+
+func (h *histogram) observe(buckets []float64, d time.Duration) {
+	seconds := d.Seconds()
+
+	i, _ := slices.BinarySearch(buckets, seconds)
+	h.counts[i]++
+	h.count++
+	h.sum += seconds
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
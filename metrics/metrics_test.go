@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/anticrew/gocron/gocrontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler().WithNamespace("app").WithBuckets(1, 0.5)
+	h.now = func() time.Time { return time.Unix(1700000000, 500000000) }
+
+	events := []gocron.JobEvent{
+		{JobName: "export", Stage: gocron.StageStart},
+		{JobName: "export", Stage: gocron.StageExec, Error: assert.AnError, Duration: 200 * time.Millisecond},
+		{JobName: "export", Stage: gocron.StageExec, Duration: 700 * time.Millisecond},
+		{JobName: "export", Stage: gocron.StageFinish},
+		{JobName: "export", Stage: gocron.StageQueue},
+		{JobName: "export", Stage: gocron.StageStart},
+		{JobName: "export", Stage: gocron.StagePanic, Error: assert.AnError, Duration: 2 * time.Second},
+		{JobName: "export", Stage: gocron.StageFinish, Error: assert.AnError},
+		{JobName: `"cleanup"`, Stage: gocron.StageSkip, Error: gocron.ErrJobPaused},
+	}
+
+	for _, event := range events {
+		h.Handle(event)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, contentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, `# HELP app_job_runs_total Number of started job runs.
+# TYPE app_job_runs_total counter
+app_job_runs_total{job_name="\"cleanup\""} 0
+app_job_runs_total{job_name="export"} 2
+# HELP app_job_failures_total Number of job errors by stage.
+# TYPE app_job_failures_total counter
+app_job_failures_total{job_name="export",stage="exec"} 1
+app_job_failures_total{job_name="export",stage="finish"} 1
+app_job_failures_total{job_name="export",stage="panic"} 1
+# HELP app_job_skips_total Number of skipped job runs.
+# TYPE app_job_skips_total counter
+app_job_skips_total{job_name="\"cleanup\""} 1
+app_job_skips_total{job_name="export"} 0
+# HELP app_job_duration_seconds Duration of job command executions.
+# TYPE app_job_duration_seconds histogram
+app_job_duration_seconds_bucket{job_name="\"cleanup\"",le="0.5"} 0
+app_job_duration_seconds_bucket{job_name="\"cleanup\"",le="1"} 0
+app_job_duration_seconds_bucket{job_name="\"cleanup\"",le="+Inf"} 0
+app_job_duration_seconds_sum{job_name="\"cleanup\""} 0
+app_job_duration_seconds_count{job_name="\"cleanup\""} 0
+app_job_duration_seconds_bucket{job_name="export",le="0.5"} 1
+app_job_duration_seconds_bucket{job_name="export",le="1"} 2
+app_job_duration_seconds_bucket{job_name="export",le="+Inf"} 3
+app_job_duration_seconds_sum{job_name="export"} 2.9
+app_job_duration_seconds_count{job_name="export"} 3
+# HELP app_job_last_success_timestamp_seconds Unix time of the last successful job command execution.
+# TYPE app_job_last_success_timestamp_seconds gauge
+app_job_last_success_timestamp_seconds{job_name="export"} 1.7000000005e+09
+`, rec.Body.String())
+}
+
+func TestHandler_Cron(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = t.Context()
+		h     = NewHandler()
+		clock = gocrontest.NewFakeClock(time.Now())
+	)
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithDefaultHandler(h))
+	c.MustAdd("@every 1s", func(context.Context) error {
+		return errors.New("failed")
+	}).WithName("export")
+
+	require.NoError(t, c.Start())
+	clock.Advance(3 * time.Second)
+	require.NoError(t, c.Shutdown(ctx))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `gocron_job_runs_total{job_name="export"} 3`)
+	assert.Contains(t, body, `gocron_job_failures_total{job_name="export",stage="exec"} 3`)
+	assert.Contains(t, body, `gocron_job_duration_seconds_count{job_name="export"} 3`)
+	assert.NotContains(t, body, "gocron_job_last_success_timestamp_seconds{")
+}
+
+func TestHandler_Remove(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler()
+
+	c := gocron.NewCron(t.Context(), gocron.WithDefaultHandler(h))
+	c.MustAdd("@yearly", func(context.Context) error { return nil }).WithName("export")
+
+	cleanup := c.MustAdd("@yearly", func(context.Context) error { return nil }).WithName("cleanup")
+
+	require.NoError(t, c.Trigger(t.Context(), "export"))
+	require.NoError(t, cleanup.RunNow(t.Context()))
+	require.NoError(t, cleanup.Remove())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `gocron_job_runs_total{job_name="export"} 1`)
+	assert.NotContains(t, body, `job_name="cleanup"`, "series of removed jobs are dropped")
+}
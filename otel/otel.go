@@ -0,0 +1,122 @@
+// Package otel provides OpenTelemetry tracing of gocron job runs
+package otel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anticrew/gocron"
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer
+const ScopeName = "github.com/anticrew/gocron/otel"
+
+// Attribute keys of run spans
+const (
+	JobNameKey    = attribute.Key("gocron.job.name")
+	JobSpecKey    = attribute.Key("gocron.job.spec")
+	RunIDKey      = attribute.Key("gocron.run.id")
+	RunTriggerKey = attribute.Key("gocron.run.trigger")
+)
+
+var _ gocron.Tracer = (*Tracer)(nil)
+
+// Tracer is a gocron.Tracer creating OpenTelemetry spans. Every run gets a gocron.run span
+// with gocron.lock, gocron.exec, gocron.refresh and gocron.unlock child spans.
+// Commands receive the context holding the gocron.exec span, so their spans are children of it
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer creates a tracer of the provider; nil provider means the global one
+func NewTracer(provider trace.TracerProvider) *Tracer {
+	if provider == nil {
+		provider = otelapi.GetTracerProvider()
+	}
+
+	return &Tracer{tracer: provider.Tracer(ScopeName)}
+}
+
+// StartRun starts the gocron.run span with the job and run attributes.
+// Scheduled runs start a new trace linked to the span of the cron context, if any, so long-lived crons
+// don't grow a single trace; manual runs are children of the caller span
+func (t *Tracer) StartRun(ctx context.Context) (context.Context, func(err error)) {
+	info, ok := gocron.RunInfoFromContext(ctx)
+	if !ok {
+		return t.start(ctx, "gocron.run")
+	}
+
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(
+			JobNameKey.String(info.JobName),
+			JobSpecKey.String(info.JobSpec),
+			RunIDKey.String(info.RunID),
+			RunTriggerKey.String(info.Trigger.String()),
+		),
+	}
+
+	if info.Trigger == gocron.TriggerSchedule {
+		opts = append(opts, trace.WithNewRoot())
+
+		if parent := trace.SpanContextFromContext(ctx); parent.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: parent}))
+		}
+	}
+
+	return t.start(ctx, "gocron.run", opts...)
+}
+
+// StartStage starts the span of the run step
+func (t *Tracer) StartStage(ctx context.Context, stage gocron.Stage) (context.Context, func(err error)) {
+	return t.start(ctx, spanName(stage))
+}
+
+func (t *Tracer) start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, func(err error)) {
+	opts = append(opts, trace.WithSpanKind(trace.SpanKindInternal))
+
+	ctx, span := t.tracer.Start(ctx, name, opts...)
+
+	return ctx, func(err error) {
+		end(span, err)
+	}
+}
+
+// end ends the span recording the error. A lock held by another run is not an error of the run
+func end(span trace.Span, err error) {
+	switch {
+	case err == nil:
+
+	case errors.Is(err, gocron.ErrLockNotAcquired):
+		span.AddEvent("lock not acquired")
+
+	default:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func spanName(stage gocron.Stage) string {
+	switch stage {
+	case gocron.StageStart:
+		return "gocron.lock"
+
+	case gocron.StageExec, gocron.StagePanic:
+		return "gocron.exec"
+
+	case gocron.StageRefresh:
+		return "gocron.refresh"
+
+	case gocron.StageFinish:
+		return "gocron.unlock"
+
+	case gocron.StageRemove, gocron.StageSkip, gocron.StageQueue, gocron.StageLimit:
+	}
+
+	return "gocron." + stage.String()
+}
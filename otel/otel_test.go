@@ -0,0 +1,137 @@
+package otel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/anticrew/gocron/gocrontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type lock struct {
+	lockErr error
+}
+
+func (l *lock) Lock(context.Context) error {
+	return l.lockErr
+}
+
+func (l *lock) Unlock(context.Context) error {
+	return nil
+}
+
+func newTracer() (*Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	return NewTracer(provider), recorder
+}
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tracer, recorder = newTracer()
+		clock            = gocrontest.NewFakeClock(time.Now())
+		cmdSpan          trace.SpanContext
+	)
+
+	// the cron context carries a long-lived span, e.g. of the application startup
+	ctx, parent := tracer.tracer.Start(t.Context(), "app")
+	defer parent.End()
+
+	c := gocron.NewCron(ctx, gocron.WithClock(clock), gocron.WithTracer(tracer))
+	c.MustAdd("@every 1s", func(ctx context.Context) error {
+		cmdSpan = trace.SpanContextFromContext(ctx)
+		return assert.AnError
+	}).WithName("export").WithLock(&lock{})
+
+	require.NoError(t, c.Start())
+	clock.Advance(time.Second)
+	require.NoError(t, c.Shutdown(ctx))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+
+	assert.Equal(t, []string{"gocron.lock", "gocron.exec", "gocron.unlock", "gocron.run"}, names)
+
+	run := spans[3]
+	assert.False(t, run.Parent().IsValid(), "scheduled runs start a new trace")
+	assert.NotEqual(t, parent.SpanContext().TraceID(), run.SpanContext().TraceID())
+	require.Len(t, run.Links(), 1)
+	assert.Equal(t, parent.SpanContext(), run.Links()[0].SpanContext)
+	assert.Equal(t, codes.Error, run.Status().Code)
+	assert.Contains(t, run.Attributes(), JobNameKey.String("export"))
+	assert.Contains(t, run.Attributes(), JobSpecKey.String("@every 1s"))
+	assert.Contains(t, run.Attributes(), RunTriggerKey.String("schedule"))
+
+	for _, span := range spans[:3] {
+		assert.Equal(t, run.SpanContext().SpanID(), span.Parent().SpanID(), span.Name())
+	}
+
+	exec := spans[1]
+	assert.Equal(t, exec.SpanContext(), cmdSpan, "command context holds the exec span")
+	assert.Equal(t, codes.Error, exec.Status().Code)
+	require.Len(t, exec.Events(), 1)
+	assert.Contains(t, exec.Events()[0].Attributes, attribute.String("exception.message", assert.AnError.Error()))
+
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Unset, spans[2].Status().Code)
+}
+
+func TestTracer_ManualRun(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newTracer()
+
+	ctx, parent := tracer.tracer.Start(t.Context(), "request")
+	defer parent.End()
+
+	c := gocron.NewCron(t.Context(), gocron.WithTracer(tracer))
+	j := c.MustAdd("@every 1s", func(context.Context) error { return nil })
+
+	require.NoError(t, j.RunNow(ctx))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	run := spans[1]
+	assert.Equal(t, "gocron.run", run.Name())
+	assert.Equal(t, parent.SpanContext(), run.Parent(), "manual runs are children of the caller span")
+	assert.Empty(t, run.Links())
+}
+
+func TestTracer_LockNotAcquired(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newTracer()
+
+	c := gocron.NewCron(t.Context(), gocron.WithTracer(tracer))
+	j := c.MustAdd("@every 1s", func(context.Context) error { return nil }).
+		WithLock(&lock{lockErr: gocron.ErrLockNotAcquired})
+
+	require.ErrorIs(t, j.RunNow(t.Context()), gocron.ErrLockNotAcquired)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	for _, span := range spans {
+		assert.Equal(t, codes.Unset, span.Status().Code, span.Name())
+	}
+
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "lock not acquired", spans[0].Events()[0].Name)
+}
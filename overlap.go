@@ -0,0 +1,161 @@
+package gocron
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// overlapGuard applies OverlapPolicy to invocations of a single job
+type overlapGuard struct {
+	policy OverlapPolicy
+
+	slot   chan struct{}
+	queued atomic.Bool
+
+	mu      sync.Mutex
+	current context.CancelFunc
+	// waiting is closed once the invocation waiting with OverlapCancelPrevious is superseded by a newer one
+	waiting chan struct{}
+}
+
+func newOverlapGuard(policy OverlapPolicy) *overlapGuard {
+	return &overlapGuard{
+		policy: policy,
+		slot:   make(chan struct{}, 1),
+	}
+}
+
+// enter waits for the invocation turn according to the policy.
+// It returns ErrJobRunning if the invocation should be skipped, ctx error if ctx is done while waiting,
+// ErrCronStopping if stop is closed while waiting, and calls onQueue before waiting.
+// cancel is called once a newer invocation replaces this one
+func (g *overlapGuard) enter(ctx context.Context, stop <-chan struct{}, cancel context.CancelFunc, onQueue func()) error {
+	switch g.policy {
+	case OverlapAllowConcurrent:
+		return nil
+
+	case OverlapSkipIfRunning:
+		if !g.tryAcquire() {
+			return ErrJobRunning
+		}
+
+	case OverlapQueueOne:
+		if g.tryAcquire() {
+			break
+		}
+
+		if !g.queued.CompareAndSwap(false, true) {
+			return ErrJobRunning
+		}
+
+		defer g.queued.Store(false)
+
+		onQueue()
+
+		if err := g.acquire(ctx, stop); err != nil {
+			return err
+		}
+
+	case OverlapCancelPrevious:
+		return g.replace(ctx, stop, cancel, onQueue)
+	}
+
+	g.mu.Lock()
+	g.current = cancel
+	g.mu.Unlock()
+
+	return nil
+}
+
+// leave releases the turn taken by enter
+func (g *overlapGuard) leave() {
+	if g.policy == OverlapAllowConcurrent {
+		return
+	}
+
+	g.mu.Lock()
+	g.current = nil
+	g.mu.Unlock()
+
+	<-g.slot
+}
+
+func (g *overlapGuard) tryAcquire() bool {
+	select {
+	case g.slot <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (g *overlapGuard) acquire(ctx context.Context, stop <-chan struct{}) error {
+	select {
+	case g.slot <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-stop:
+		return ErrCronStopping
+	}
+}
+
+// replace cancels the running invocation and waits for its turn. Only the newest invocation waits:
+// the previously waiting one is superseded and skipped with ErrJobRunning
+func (g *overlapGuard) replace(ctx context.Context, stop <-chan struct{}, cancel context.CancelFunc, onQueue func()) error {
+	g.mu.Lock()
+
+	if g.waiting != nil {
+		close(g.waiting)
+	}
+
+	waiting := make(chan struct{})
+	g.waiting = waiting
+
+	if g.current != nil {
+		g.current()
+	}
+
+	g.mu.Unlock()
+
+	if !g.tryAcquire() {
+		onQueue()
+
+		select {
+		case g.slot <- struct{}{}:
+		case <-waiting:
+			return ErrJobRunning
+		case <-ctx.Done():
+			g.stopWaiting(waiting)
+			return ctx.Err()
+		case <-stop:
+			g.stopWaiting(waiting)
+			return ErrCronStopping
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// superseded while taking the turn
+	if g.waiting != waiting {
+		<-g.slot
+		return ErrJobRunning
+	}
+
+	g.waiting = nil
+	g.current = cancel
+
+	return nil
+}
+
+// stopWaiting forgets the waiting invocation unless a newer one has already superseded it
+func (g *overlapGuard) stopWaiting(waiting chan struct{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.waiting == waiting {
+		g.waiting = nil
+	}
+}
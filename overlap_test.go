@@ -0,0 +1,252 @@
+package gocron
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type overlapEvents struct {
+	mu     sync.Mutex
+	events []JobEvent
+}
+
+func (h *overlapEvents) Handle(event JobEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if event.Stage == StageSkip || event.Stage == StageQueue {
+		h.events = append(h.events, event)
+	}
+}
+
+func (h *overlapEvents) stages() []Stage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stages := make([]Stage, 0, len(h.events))
+	for _, e := range h.events {
+		stages = append(stages, e.Stage)
+	}
+
+	return stages
+}
+
+func TestJob_OverlapPolicy(t *testing.T) {
+	t.Parallel()
+
+	const wait = 5 * time.Second
+
+	tests := []struct {
+		name          string
+		policy        OverlapPolicy
+		runs          int
+		expectedRuns  int32
+		expectedCalls int32
+		cancelled     int32
+		stages        []Stage
+	}{
+		{
+			name:          "allow concurrent",
+			policy:        OverlapAllowConcurrent,
+			runs:          3,
+			expectedRuns:  3,
+			expectedCalls: 3,
+			stages:        []Stage{},
+		},
+		{
+			name:          "skip if running",
+			policy:        OverlapSkipIfRunning,
+			runs:          3,
+			expectedRuns:  1,
+			expectedCalls: 1,
+			stages:        []Stage{StageSkip, StageSkip},
+		},
+		{
+			name:          "queue one",
+			policy:        OverlapQueueOne,
+			runs:          3,
+			expectedRuns:  1,
+			expectedCalls: 2,
+			stages:        []Stage{StageQueue, StageSkip},
+		},
+		{
+			name:          "cancel previous",
+			policy:        OverlapCancelPrevious,
+			runs:          2,
+			expectedRuns:  1,
+			expectedCalls: 2,
+			cancelled:     1,
+			stages:        []Stage{StageQueue},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				running   atomic.Int32
+				calls     atomic.Int32
+				cancelled atomic.Int32
+				release   = make(chan struct{})
+				handler   = &overlapEvents{}
+			)
+
+			j := newJob(t.Context(), "spec", func(ctx context.Context) error {
+				calls.Add(1)
+				running.Add(1)
+				defer running.Add(-1)
+
+				select {
+				case <-release:
+					return nil
+				case <-ctx.Done():
+					cancelled.Add(1)
+					return ctx.Err()
+				}
+			})
+			j.WithOverlapPolicy(tc.policy).WithHandler(handler)
+
+			var wg sync.WaitGroup
+			for i := range tc.runs {
+				wg.Go(j.Run)
+
+				// every run either calls the command or reports skip or queue before the next one starts
+				require.Eventually(t, func() bool {
+					return int(calls.Load())+len(handler.stages()) > i
+				}, wait, time.Millisecond)
+			}
+
+			require.Eventually(t, func() bool {
+				return running.Load() == tc.expectedRuns && len(handler.stages()) == len(tc.stages)
+			}, wait, time.Millisecond)
+
+			close(release)
+			wg.Wait()
+
+			assert.Equal(t, tc.expectedCalls, calls.Load())
+			assert.Equal(t, tc.cancelled, cancelled.Load())
+			assert.Equal(t, tc.stages, handler.stages())
+		})
+	}
+}
+
+func TestJob_OverlapCancelPrevious(t *testing.T) {
+	t.Parallel()
+
+	var (
+		handler  = &overlapEvents{}
+		lock     = &ctxLock{}
+		started  = make(chan int, 3)
+		release  = make(chan struct{})
+		calls    atomic.Int32
+		finished = make(chan error, 3)
+	)
+
+	j := newJob(t.Context(), "spec", func(ctx context.Context) error {
+		call := int(calls.Add(1))
+		started <- call
+
+		if call == 1 {
+			// the first run is slow to exit after cancellation
+			<-ctx.Done()
+			<-release
+		}
+
+		return ctx.Err()
+	})
+	j.WithOverlapPolicy(OverlapCancelPrevious).WithHandler(handler).WithLock(lock)
+
+	run := func() {
+		finished <- j.RunNow(t.Context())
+	}
+
+	go run()
+	require.Equal(t, 1, <-started)
+
+	go run()
+	require.Eventually(t, func() bool { return len(handler.stages()) == 1 }, 5*time.Second, time.Millisecond)
+
+	go run()
+	require.ErrorIs(t, <-finished, ErrJobRunning, "the second run is superseded by the third one")
+
+	close(release)
+	require.ErrorIs(t, <-finished, context.Canceled)
+	require.NoError(t, <-finished, "the third run acquires the lock released by the cancelled run")
+
+	require.Equal(t, 2, <-started)
+	assert.EqualValues(t, 2, calls.Load())
+	assert.False(t, lock.isHeld())
+	assert.ElementsMatch(t, []Stage{StageQueue, StageSkip, StageQueue}, handler.stages())
+}
+
+func TestCron_OverlapStopsWaitingOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy OverlapPolicy
+	}{
+		{name: "queue one", policy: OverlapQueueOne},
+		{name: "cancel previous", policy: OverlapCancelPrevious},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				handler = &overlapEvents{}
+				started = make(chan struct{}, 1)
+				release = make(chan struct{})
+				calls   atomic.Int32
+			)
+
+			c := NewCron(t.Context())
+
+			j := c.MustAdd("@yearly", func(context.Context) error {
+				if calls.Add(1) == 1 {
+					started <- struct{}{}
+					// the first run ignores cancellation to keep the second one waiting
+					<-release
+				}
+
+				return nil
+			}).WithOverlapPolicy(tc.policy).WithHandler(handler)
+
+			require.NoError(t, c.Start())
+
+			done := make(chan error, 1)
+			go func() {
+				done <- j.RunNow(t.Context())
+			}()
+			<-started
+
+			waiting := make(chan error, 1)
+			go func() {
+				waiting <- j.RunNow(t.Context())
+			}()
+			require.Eventually(t, func() bool { return len(handler.stages()) == 1 }, 5*time.Second, time.Millisecond)
+
+			shutdown := make(chan error, 1)
+			go func() {
+				shutdown <- c.Shutdown(t.Context())
+			}()
+
+			require.ErrorIs(t, <-waiting, ErrCronStopping, "waiting runs don't block shutdown")
+
+			close(release)
+			require.NoError(t, <-done)
+			require.NoError(t, <-shutdown)
+
+			assert.EqualValues(t, 1, calls.Load())
+			assert.Equal(t, []Stage{StageQueue, StageSkip}, handler.stages())
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Package redislock provides a distributed gocron.RenewableLock backed by Redis
+package redislock
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultPrefix is the default prefix of lock keys
+const DefaultPrefix = "gocron:lock:"
+
+var (
+	// unlockScript deletes the key if it still holds the token of the run
+	unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+	// refreshScript extends the key TTL if it still holds the token of the run
+	refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+)
+
+var _ gocron.RenewableLock = (*Lock)(nil)
+
+// held is a lock acquired by a run
+type held struct {
+	key   string
+	token string
+}
+
+// runLock identifies a key held by a run, a run ID alone is not unique across jobs sharing the lock
+type runLock struct {
+	key   string
+	runID string
+}
+
+// Lock is a gocron.RenewableLock holding a Redis key with a random token for the lease duration.
+// The key is derived from the job name, so jobs must have the same name in all replicas
+type Lock struct {
+	client  redis.UniversalClient
+	ttl     time.Duration
+	refresh time.Duration
+	prefix  string
+	key     string
+
+	mu   sync.Mutex
+	runs map[runLock]held
+}
+
+// New creates a lock with the given lease duration. The lease is refreshed every third of ttl by default
+func New(client redis.UniversalClient, ttl time.Duration) *Lock {
+	return &Lock{
+		client:  client,
+		ttl:     ttl,
+		refresh: ttl / 3,
+		prefix:  DefaultPrefix,
+		runs:    make(map[runLock]held),
+	}
+}
+
+// WithKey sets the key used instead of the job name
+func (l *Lock) WithKey(key string) *Lock {
+	l.key = key
+	return l
+}
+
+// WithPrefix sets the prefix of lock keys; DefaultPrefix is used by default
+func (l *Lock) WithPrefix(prefix string) *Lock {
+	l.prefix = prefix
+	return l
+}
+
+// WithRefreshInterval sets the interval of lease refreshing; non-positive value disables refreshing
+func (l *Lock) WithRefreshInterval(d time.Duration) *Lock {
+	l.refresh = d
+	return l
+}
+
+// Key returns the lock key of the given job name
+func (l *Lock) Key(name string) string {
+	if l.key != "" {
+		name = l.key
+	}
+
+	return l.prefix + name
+}
+
+// Lock tries to set the key of the job running in ctx without blocking.
+// It returns gocron.ErrLockNotAcquired if the key is held by another run
+func (l *Lock) Lock(ctx context.Context) error {
+	run, err := l.runLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	h := held{
+		key:   run.key,
+		token: rand.Text(),
+	}
+
+	ok, err := l.client.SetNX(ctx, h.key, h.token, l.ttl).Result()
+	if err != nil {
+		return fmt.Errorf("redis.SetNX: %w", err)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s", gocron.ErrLockNotAcquired, h.key)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.runs[run] = h
+
+	return nil
+}
+
+// Unlock deletes the key if it's still held by the run in ctx
+func (l *Lock) Unlock(ctx context.Context) error {
+	run, err := l.runLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	h, ok := l.runs[run]
+	delete(l.runs, run)
+	l.mu.Unlock()
+
+	if !ok {
+		return gocron.ErrLockNotHeld
+	}
+
+	return l.eval(ctx, unlockScript, h)
+}
+
+// Refresh extends the lease of the key held by the run in ctx
+func (l *Lock) Refresh(ctx context.Context) error {
+	run, err := l.runLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	h, ok := l.runs[run]
+	l.mu.Unlock()
+
+	if !ok {
+		return gocron.ErrLockNotHeld
+	}
+
+	return l.eval(ctx, refreshScript, h, l.ttl.Milliseconds())
+}
+
+// RefreshInterval returns the interval of lease refreshing
+func (l *Lock) RefreshInterval() time.Duration {
+	return l.refresh
+}
+
+// runLock returns the key of the job running in ctx along with the run ID
+func (l *Lock) runLock(ctx context.Context) (runLock, error) {
+	name, err := gocron.LockName(ctx, l.key)
+	if err != nil {
+		return runLock{}, err
+	}
+
+	info, _ := gocron.RunInfoFromContext(ctx)
+
+	return runLock{key: l.prefix + name, runID: info.RunID}, nil
+}
+
+// eval runs the compare-and-act script for the held key
+func (l *Lock) eval(ctx context.Context, script *redis.Script, h held, args ...any) error {
+	res, err := script.Run(ctx, l.client, []string{h.key}, append([]any{h.token}, args...)...).Int64()
+	if err != nil {
+		return fmt.Errorf("redis.Eval: %w", err)
+	}
+
+	if res == 0 {
+		return fmt.Errorf("%w: %s", gocron.ErrLockNotHeld, h.key)
+	}
+
+	return nil
+}
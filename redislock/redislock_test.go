@@ -0,0 +1,184 @@
+package redislock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/anticrew/gocron"
+	"github.com/anticrew/gocron/gocrontest"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T) (*miniredis.Miniredis, redis.UniversalClient) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return server, client
+}
+
+func TestLock_Replicas(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server, client = newClient(t)
+		first          = New(client, time.Minute)
+		second         = New(client, time.Minute)
+		ctx            = gocrontest.RunContext(t.Context(), "report", "run")
+		cleanup        = gocrontest.RunContext(t.Context(), "cleanup", "run")
+	)
+
+	require.NoError(t, first.Lock(ctx))
+	assert.Equal(t, time.Minute, server.TTL("gocron:lock:report"))
+
+	require.ErrorIs(t, second.Lock(ctx), gocron.ErrLockNotAcquired)
+	require.ErrorIs(t, second.Unlock(ctx), gocron.ErrLockNotHeld)
+	require.NoError(t, second.Lock(cleanup), "other jobs are not locked")
+
+	require.NoError(t, first.Unlock(ctx))
+	assert.False(t, server.Exists("gocron:lock:report"))
+
+	require.NoError(t, second.Lock(ctx))
+	require.NoError(t, second.Unlock(cleanup), "locks of jobs with the same run ID are held separately")
+	assert.True(t, server.Exists("gocron:lock:report"))
+	assert.False(t, server.Exists("gocron:lock:cleanup"))
+}
+
+func TestLock_Expired(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server, client = newClient(t)
+		first          = New(client, time.Minute)
+		second         = New(client, time.Minute)
+		ctx            = gocrontest.RunContext(t.Context(), "report", "run")
+	)
+
+	require.NoError(t, first.Lock(ctx))
+	server.FastForward(time.Minute)
+
+	require.NoError(t, second.Lock(ctx))
+
+	require.ErrorIs(t, first.Refresh(ctx), gocron.ErrLockNotHeld)
+	require.ErrorIs(t, first.Unlock(ctx), gocron.ErrLockNotHeld)
+	assert.True(t, server.Exists("gocron:lock:report"), "lock of another replica is kept")
+
+	require.NoError(t, second.Unlock(ctx))
+}
+
+func TestLock_Refresh(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server, client = newClient(t)
+		lock           = New(client, time.Minute)
+		ctx            = gocrontest.RunContext(t.Context(), "report", "run")
+	)
+
+	assert.Equal(t, 20*time.Second, lock.RefreshInterval())
+	require.ErrorIs(t, lock.Refresh(ctx), gocron.ErrLockNotHeld)
+
+	require.NoError(t, lock.Lock(ctx))
+	server.FastForward(50 * time.Second)
+	assert.Equal(t, 10*time.Second, server.TTL("gocron:lock:report"))
+
+	require.NoError(t, lock.Refresh(ctx))
+	assert.Equal(t, time.Minute, server.TTL("gocron:lock:report"))
+}
+
+func TestLock_Key(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		lock     func(client redis.UniversalClient) *Lock
+		ctx      context.Context
+		expected string
+		err      error
+	}{
+		{
+			name:     "uses job name",
+			lock:     func(client redis.UniversalClient) *Lock { return New(client, time.Minute) },
+			ctx:      gocrontest.RunContext(t.Context(), "report", "run"),
+			expected: "gocron:lock:report",
+		},
+		{
+			name: "prefers lock key",
+			lock: func(client redis.UniversalClient) *Lock {
+				return New(client, time.Minute).WithKey("shared")
+			},
+			ctx:      gocrontest.RunContext(t.Context(), "report", "run"),
+			expected: "gocron:lock:shared",
+		},
+		{
+			name: "uses prefix",
+			lock: func(client redis.UniversalClient) *Lock {
+				return New(client, time.Minute).WithPrefix("app:")
+			},
+			ctx:      gocrontest.RunContext(t.Context(), "report", "run"),
+			expected: "app:report",
+		},
+		{
+			name: "requires key",
+			lock: func(client redis.UniversalClient) *Lock { return New(client, time.Minute) },
+			ctx:  t.Context(),
+			err:  gocron.ErrNoLockName,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, client := newClient(t)
+			lock := tc.lock(client)
+
+			err := lock.Lock(tc.ctx)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, server.Exists(tc.expected))
+
+			info, _ := gocron.RunInfoFromContext(tc.ctx)
+			assert.Equal(t, tc.expected, lock.Key(info.JobName))
+		})
+	}
+}
+
+func TestLock_Job(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server, client = newClient(t)
+		lock           = New(client, time.Minute).WithRefreshInterval(10 * time.Millisecond)
+	)
+
+	job := gocron.NewCron(t.Context()).MustAdd("@every 1h", func(ctx context.Context) error {
+		// another replica takes the key once the lease expires
+		server.FastForward(time.Minute)
+		if err := server.Set("gocron:lock:report", "replica"); err != nil {
+			return err
+		}
+
+		<-ctx.Done()
+
+		return context.Cause(ctx)
+	}).WithName("report").WithLock(lock)
+
+	err := job.RunNow(t.Context())
+	require.ErrorIs(t, err, gocron.ErrLockLost)
+	require.ErrorIs(t, err, gocron.ErrLockNotHeld)
+
+	value, err := server.Get("gocron:lock:report")
+	require.NoError(t, err)
+	assert.Equal(t, "replica", value)
+}
@@ -0,0 +1,101 @@
+package gocron
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// ConstantBackoff waits the same delay before every retry
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// LinearBackoff waits step before the first retry and increases the delay by step before each next one,
+// limited by maxDelay; non-positive maxDelay disables the limit
+func LinearBackoff(step, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		return limitDelay(step*time.Duration(attempt-1), maxDelay)
+	}
+}
+
+// ExponentialBackoff waits base before the first retry and doubles the delay before each next one,
+// limited by maxDelay; non-positive maxDelay disables the limit
+func ExponentialBackoff(base, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		delay := base
+		for range attempt - 2 {
+			if delay > math.MaxInt64/2 {
+				delay = math.MaxInt64
+				break
+			}
+
+			delay *= 2
+
+			if maxDelay > 0 && delay >= maxDelay {
+				break
+			}
+		}
+
+		return limitDelay(delay, maxDelay)
+	}
+}
+
+func limitDelay(delay, maxDelay time.Duration) time.Duration {
+	if maxDelay > 0 {
+		return min(delay, maxDelay)
+	}
+
+	return delay
+}
+
+// shouldRetry reports whether the attempt that failed with err should be followed by another one
+func (p RetryPolicy) shouldRetry(attempt int, err error) bool {
+	if err == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// delay returns the jittered delay before the attempt clamped to [0, math.MaxInt64]
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	if p.Backoff == nil {
+		return 0
+	}
+
+	delay := p.Backoff(attempt)
+	if p.Jitter <= 0 || delay <= 0 {
+		return delay
+	}
+
+	jitter := min(p.Jitter, 1)
+	//nolint:gosec // jitter doesn't need a cryptographically secure source
+	jittered := float64(delay) * (1 + jitter*(2*rand.Float64()-1))
+
+	// float64(math.MaxInt64) is rounded up to 2^63, which overflows on conversion
+	if jittered >= float64(math.MaxInt64) {
+		return math.MaxInt64
+	}
+
+	return time.Duration(max(jittered, 0))
+}
+
+// wait waits the delay before the attempt. It returns ctx error if ctx is done
+// and ErrCronStopping if stop is closed earlier
+func (p RetryPolicy) wait(ctx context.Context, stop <-chan struct{}, attempt int) error {
+	timer := time.NewTimer(p.delay(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-stop:
+		return ErrCronStopping
+	}
+}
@@ -0,0 +1,184 @@
+package gocron
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		backoff  Backoff
+		expected []time.Duration
+	}{
+		{
+			name:     "constant",
+			backoff:  ConstantBackoff(time.Second),
+			expected: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:     "linear",
+			backoff:  LinearBackoff(time.Second, 0),
+			expected: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		{
+			name:     "linear limited",
+			backoff:  LinearBackoff(time.Second, 2*time.Second),
+			expected: []time.Duration{time.Second, 2 * time.Second, 2 * time.Second},
+		},
+		{
+			name:     "exponential",
+			backoff:  ExponentialBackoff(time.Second, 0),
+			expected: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		{
+			name:     "exponential limited",
+			backoff:  ExponentialBackoff(time.Second, 3*time.Second),
+			expected: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := make([]time.Duration, 0, len(tc.expected))
+			for attempt := 2; attempt < len(tc.expected)+2; attempt++ {
+				actual = append(actual, tc.backoff(attempt))
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("exponential overflow", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, time.Duration(math.MaxInt64), ExponentialBackoff(time.Second, 0)(100))
+	})
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{
+		Backoff: ConstantBackoff(time.Second),
+		Jitter:  0.5,
+	}
+
+	for range 100 {
+		delay := p.delay(2)
+		assert.GreaterOrEqual(t, delay, 500*time.Millisecond)
+		assert.LessOrEqual(t, delay, 1500*time.Millisecond)
+	}
+
+	assert.Zero(t, RetryPolicy{}.delay(2))
+
+	saturated := RetryPolicy{
+		Backoff: ExponentialBackoff(time.Second, 0),
+		Jitter:  1,
+	}
+
+	for range 100 {
+		assert.GreaterOrEqual(t, saturated.delay(100), time.Duration(0))
+	}
+}
+
+func TestJob_Retry(t *testing.T) {
+	t.Parallel()
+
+	errPermanent := errors.New("permanent")
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		errs     []error
+		timeout  time.Duration
+		expected []error
+	}{
+		{
+			name:     "no retry by default",
+			errs:     []error{assert.AnError, nil},
+			expected: []error{assert.AnError},
+		},
+		{
+			name:     "retries until success",
+			policy:   RetryPolicy{MaxAttempts: 5},
+			errs:     []error{assert.AnError, assert.AnError, nil},
+			expected: []error{assert.AnError, assert.AnError, nil},
+		},
+		{
+			name:     "stops after max attempts",
+			policy:   RetryPolicy{MaxAttempts: 2, Backoff: ConstantBackoff(time.Millisecond)},
+			errs:     []error{assert.AnError, assert.AnError, nil},
+			expected: []error{assert.AnError, assert.AnError},
+		},
+		{
+			name: "stops on non-retryable error",
+			policy: RetryPolicy{
+				MaxAttempts: 5,
+				Retryable: func(err error) bool {
+					return !errors.Is(err, errPermanent)
+				},
+			},
+			errs:     []error{assert.AnError, errPermanent, nil},
+			expected: []error{assert.AnError, errPermanent},
+		},
+		{
+			name:     "stops on timeout",
+			policy:   RetryPolicy{MaxAttempts: 5, Backoff: ConstantBackoff(time.Minute)},
+			timeout:  50 * time.Millisecond,
+			errs:     []error{assert.AnError, nil},
+			expected: []error{assert.AnError},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			j := newJob(t.Context(), "spec", func(context.Context) error {
+				err := tc.errs[calls]
+				calls++
+
+				return err
+			})
+
+			var (
+				errs     []error
+				attempts []int
+			)
+
+			j.WithRetry(tc.policy).
+				WithTimeout(tc.timeout).
+				WithHandler(HandlerFunc(func(event JobEvent) {
+					if event.Stage != StageExec {
+						return
+					}
+
+					errs = append(errs, event.Error)
+					attempts = append(attempts, event.Attempt)
+				}))
+
+			err := j.RunNow(t.Context())
+			if last := tc.expected[len(tc.expected)-1]; last != nil {
+				assert.ErrorIs(t, err, last)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expected, errs)
+			for i, attempt := range attempts {
+				assert.Equal(t, i+1, attempt)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package gocron
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/anticrew/gocron/internal"
+)
+
+const runIDSize = 16
+
+// activeRun is a running job invocation
+type activeRun struct {
+	info   RunInfo
+	job    *job
+	cancel context.CancelFunc
+}
+
+// runSet tracks running job invocations of a cron
+type runSet struct {
+	group internal.Group
+	// scheduled counts runs started by the schedule, which are stopped on leadership loss
+	scheduled internal.Group
+
+	mu   sync.Mutex
+	runs map[*activeRun]struct{}
+}
+
+func newRunSet() *runSet {
+	return &runSet{
+		runs: make(map[*activeRun]struct{}),
+	}
+}
+
+func (s *runSet) add(r *activeRun) {
+	s.group.Add()
+	if r.info.Trigger == TriggerSchedule {
+		s.scheduled.Add()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.runs[r] = struct{}{}
+}
+
+func (s *runSet) done(r *activeRun) {
+	s.mu.Lock()
+	delete(s.runs, r)
+	s.mu.Unlock()
+
+	if r.info.Trigger == TriggerSchedule {
+		s.scheduled.Done()
+	}
+	s.group.Done()
+}
+
+// idle returns a channel closed once all runs active at the moment of the call are done
+func (s *runSet) idle() <-chan struct{} {
+	return s.group.Idle()
+}
+
+// scheduledIdle returns a channel closed once all runs started by the schedule
+// and active at the moment of the call are done
+func (s *runSet) scheduledIdle() <-chan struct{} {
+	return s.scheduled.Idle()
+}
+
+// cancel cancels contexts of all active runs
+func (s *runSet) cancel() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for r := range s.runs {
+		r.cancel()
+	}
+}
+
+// cancelScheduled cancels contexts of active runs started by the schedule
+func (s *runSet) cancelScheduled() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for r := range s.runs {
+		if r.info.Trigger == TriggerSchedule {
+			r.cancel()
+		}
+	}
+}
+
+// refs returns references to all active runs sorted by job name and run ID
+func (s *runSet) refs() []RunRef {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refs := make([]RunRef, 0, len(s.runs))
+	for r := range s.runs {
+		refs = append(refs, RunRef{
+			JobName: r.job.getName(),
+			RunID:   r.info.RunID,
+		})
+	}
+
+	slices.SortFunc(refs, func(a, b RunRef) int {
+		return cmp.Or(strings.Compare(a.JobName, b.JobName), strings.Compare(a.RunID, b.RunID))
+	})
+
+	return refs
+}
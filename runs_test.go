@@ -0,0 +1,84 @@
+package gocron
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSet(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s         = newRunSet()
+		first     = newJob(t.Context(), "spec", nil)
+		second    = newJob(t.Context(), "spec", nil)
+		ctx, stop = context.WithCancel(t.Context())
+	)
+
+	t.Cleanup(stop)
+
+	first.WithName("b")
+	second.WithName("a")
+
+	runs := []*activeRun{
+		{info: RunInfo{RunID: "2"}, job: first, cancel: stop},
+		{info: RunInfo{RunID: "1"}, job: first, cancel: stop},
+		{info: RunInfo{RunID: "3"}, job: second, cancel: stop},
+	}
+
+	for _, r := range runs {
+		s.add(r)
+	}
+
+	idle := s.idle()
+
+	assert.Equal(t, []RunRef{
+		{JobName: "a", RunID: "3"},
+		{JobName: "b", RunID: "1"},
+		{JobName: "b", RunID: "2"},
+	}, s.refs())
+
+	s.cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	for _, r := range runs {
+		s.done(r)
+	}
+
+	<-idle
+	assert.Empty(t, s.refs())
+}
+
+func TestRunSet_Scheduled(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s                          = newRunSet()
+		j                          = newJob(t.Context(), "spec", nil)
+		scheduledCtx, stopSchedule = context.WithCancel(t.Context())
+		manualCtx, stopManual      = context.WithCancel(t.Context())
+	)
+
+	t.Cleanup(stopManual)
+
+	scheduled := &activeRun{info: RunInfo{RunID: "1", Trigger: TriggerSchedule}, job: j, cancel: stopSchedule}
+	manual := &activeRun{info: RunInfo{RunID: "2", Trigger: TriggerManual}, job: j, cancel: stopManual}
+
+	s.add(scheduled)
+	s.add(manual)
+
+	idle := s.scheduledIdle()
+
+	s.cancelScheduled()
+	require.ErrorIs(t, scheduledCtx.Err(), context.Canceled)
+	require.NoError(t, manualCtx.Err())
+
+	s.done(scheduled)
+	<-idle
+
+	assert.Len(t, s.refs(), 1)
+	s.done(manual)
+}
@@ -0,0 +1,247 @@
+package gocron
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	c "github.com/robfig/cron/v3"
+)
+
+// realClock is the Clock of the system time
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// timedJob is a job receiving the time its scheduled run was planned at
+type timedJob interface {
+	runAt(scheduled time.Time)
+}
+
+// entry is a scheduled job
+type entry struct {
+	id       c.EntryID
+	schedule c.Schedule
+	job      c.Job
+
+	next, prev time.Time
+	timer      Timer
+	// armed counts arm calls, so a timer callback that has already started can tell it was replaced
+	armed uint64
+}
+
+// scheduler runs jobs by their schedules using the clock.
+// robfig's cron parses specs and wraps jobs with chains, but its own run loop is never started
+type scheduler struct {
+	cron  *c.Cron
+	clock Clock
+
+	mu      sync.Mutex
+	running bool
+	entries map[c.EntryID]*entry
+	runs    sync.WaitGroup
+}
+
+func newScheduler(cron *c.Cron, clock Clock) *scheduler {
+	return &scheduler{
+		cron:    cron,
+		clock:   clock,
+		entries: make(map[c.EntryID]*entry),
+	}
+}
+
+// add schedules the job by spec
+func (s *scheduler) add(spec string, job c.Job) (c.EntryID, error) {
+	id, err := s.cron.AddJob(spec, job)
+	if err != nil {
+		return 0, err
+	}
+
+	registered := s.cron.Entry(id)
+	e := &entry{
+		id:       id,
+		schedule: registered.Schedule,
+		job:      registered.WrappedJob,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[id] = e
+	if s.running {
+		s.arm(e, s.now())
+	}
+
+	return id, nil
+}
+
+// remove unschedules the entry; running invocations are not interrupted
+func (s *scheduler) remove(id c.EntryID) {
+	s.cron.Remove(id)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[id]; ok {
+		e.disarm()
+		delete(s.entries, id)
+	}
+}
+
+// start schedules the next runs of all entries
+func (s *scheduler) start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+
+	s.running = true
+
+	now := s.now()
+	for _, e := range s.entries {
+		e.next = time.Time{}
+		s.arm(e, now)
+	}
+}
+
+// stop cancels the next runs and returns a channel closed once the started runs return
+func (s *scheduler) stop() <-chan struct{} {
+	s.mu.Lock()
+
+	s.running = false
+	for _, e := range s.entries {
+		e.disarm()
+		e.next = time.Time{}
+	}
+
+	s.mu.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		s.runs.Wait()
+	}()
+
+	return stopped
+}
+
+// times returns the next and previous run times of the entry
+func (s *scheduler) times(id c.EntryID) (next, prev time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[id]; ok {
+		return e.next, e.prev
+	}
+
+	return time.Time{}, time.Time{}
+}
+
+// order returns the entry IDs ordered by the next run time, entries without it go last
+func (s *scheduler) order() []c.EntryID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]*entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+
+	slices.SortFunc(entries, func(a, b *entry) int {
+		switch {
+		case a.next.IsZero() != b.next.IsZero():
+			if a.next.IsZero() {
+				return 1
+			}
+
+			return -1
+
+		case !a.next.Equal(b.next):
+			return a.next.Compare(b.next)
+
+		default:
+			return cmp.Compare(a.id, b.id)
+		}
+	})
+
+	ids := make([]c.EntryID, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.id)
+	}
+
+	return ids
+}
+
+// arm schedules the next run of the entry after now; s.mu must be held
+func (s *scheduler) arm(e *entry, now time.Time) {
+	// schedules return a time after the given one, so a timer fired early doesn't repeat the run
+	e.next = e.schedule.Next(later(now, e.next))
+	if e.next.IsZero() {
+		return
+	}
+
+	e.armed++
+	armed := e.armed
+
+	e.timer = s.clock.AfterFunc(e.next.Sub(now), func() {
+		s.fire(e, armed)
+	})
+}
+
+// fire schedules the next run of the entry and runs the job, passing it the planned time of the run.
+// Timer.Stop can't cancel a callback that has already started, so callbacks of disarmed or re-armed timers
+// are detected by armed and ignored
+func (s *scheduler) fire(e *entry, armed uint64) {
+	s.mu.Lock()
+
+	if !s.running || s.entries[e.id] != e || e.armed != armed {
+		s.mu.Unlock()
+		return
+	}
+
+	scheduled := e.next
+	e.prev = e.next
+	s.arm(e, s.now())
+	s.runs.Add(1)
+
+	s.mu.Unlock()
+
+	defer s.runs.Done()
+
+	if j, ok := e.job.(timedJob); ok {
+		j.runAt(scheduled)
+		return
+	}
+
+	e.job.Run()
+}
+
+func (s *scheduler) now() time.Time {
+	return s.clock.Now().In(s.cron.Location())
+}
+
+func (e *entry) disarm() {
+	e.armed++
+
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
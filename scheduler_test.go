@@ -0,0 +1,100 @@
+package gocron
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	c "github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock keeps scheduled calls to make them by hand, even after they are stopped
+type manualClock struct {
+	now time.Time
+
+	mu     sync.Mutex
+	timers []*manualTimer
+}
+
+func (m *manualClock) Now() time.Time {
+	return m.now
+}
+
+func (m *manualClock) AfterFunc(_ time.Duration, f func()) Timer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := &manualTimer{f: f}
+	m.timers = append(m.timers, t)
+
+	return t
+}
+
+// pending returns the calls neither stopped nor made
+func (m *manualClock) pending() []*manualTimer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var pending []*manualTimer
+	for _, t := range m.timers {
+		if !t.stopped && !t.fired {
+			pending = append(pending, t)
+		}
+	}
+
+	return pending
+}
+
+type manualTimer struct {
+	f       func()
+	stopped bool
+	fired   bool
+}
+
+// fire makes the call like a timer which has fired before Stop
+func (t *manualTimer) fire() {
+	t.fired = true
+	t.f()
+}
+
+func (t *manualTimer) Stop() bool {
+	stopped := !t.stopped && !t.fired
+	t.stopped = true
+
+	return stopped
+}
+
+func TestScheduler_StaleTimer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		clock = &manualClock{now: time.Now()}
+		s     = newScheduler(c.New(), clock)
+		runs  atomic.Int32
+	)
+
+	_, err := s.add("@every 1s", c.FuncJob(func() { runs.Add(1) }))
+	require.NoError(t, err)
+
+	s.start()
+	require.Len(t, clock.pending(), 1)
+	stale := clock.pending()[0]
+
+	// the callback has started, but waits for the scheduler while it's restarted
+	<-s.stop()
+	s.start()
+	stale.fire()
+
+	assert.Zero(t, runs.Load(), "the stale callback doesn't run the job")
+	require.Len(t, clock.pending(), 1, "the entry has one timer")
+
+	clock.pending()[0].fire()
+	assert.EqualValues(t, 1, runs.Load())
+	assert.Len(t, clock.pending(), 1)
+
+	<-s.stop()
+	assert.Empty(t, clock.pending())
+}
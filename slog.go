@@ -2,6 +2,7 @@ package gocron
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/anticrew/gocron/internal"
@@ -34,22 +35,31 @@ func (s *SlogHandler) WithEvent(leveler slog.Leveler) *SlogHandler {
 	return s
 }
 
-// Handle logs a job event based on stage and error presence.
+// Handle logs a job event like HandleContext with context.Background.
 func (s *SlogHandler) Handle(event JobEvent) {
-	if event.Error != nil {
-		s.handleError(event)
+	s.HandleContext(context.Background(), event)
+}
+
+// HandleContext logs a job event with the run context based on stage and error presence.
+// Skipped and queued runs are logged at the event level with the reason.
+func (s *SlogHandler) HandleContext(ctx context.Context, event JobEvent) {
+	if event.Error != nil && event.Stage != StageSkip && event.Stage != StageQueue {
+		s.handleError(ctx, event)
 		return
 	}
 
-	s.handleEvent(event)
+	s.handleEvent(ctx, event)
 }
 
-func (s *SlogHandler) handleError(event JobEvent) {
+func (s *SlogHandler) handleError(ctx context.Context, event JobEvent) {
 	if s.errorLeveler == nil {
 		return
 	}
 
-	var msg string
+	var (
+		msg   string
+		attrs = append(s.attrs(event), slog.Any("error", event.Error))
+	)
 
 	switch event.Stage {
 	case StageStart:
@@ -60,20 +70,37 @@ func (s *SlogHandler) handleError(event JobEvent) {
 
 	case StageFinish:
 		msg = "can't finish job"
+
+	case StagePanic:
+		msg = "job panicked"
+
+		var panicErr *PanicError
+		if errors.As(event.Error, &panicErr) {
+			attrs = append(attrs, slog.String("stack", string(panicErr.Stack)))
+		}
+
+	case StageRefresh:
+		msg = "can't refresh job lock"
+
+	case StageLimit:
+		msg = "job hit concurrency limit"
+
+	case StageRemove, StageSkip, StageQueue:
+		msg = "job failed"
 	}
 
-	s.log.LogAttrs(context.Background(), s.errorLeveler.Level(), msg,
-		slog.String("spec", event.JobSpec),
-		slog.String("name", event.JobName),
-		slog.Any("error", event.Error))
+	s.log.LogAttrs(ctx, s.errorLeveler.Level(), msg, attrs...)
 }
 
-func (s *SlogHandler) handleEvent(event JobEvent) {
+func (s *SlogHandler) handleEvent(ctx context.Context, event JobEvent) {
 	if s.eventLeveler == nil {
 		return
 	}
 
-	var msg string
+	var (
+		msg   string
+		attrs = s.attrs(event)
+	)
 
 	switch event.Stage {
 	case StageStart:
@@ -84,9 +111,44 @@ func (s *SlogHandler) handleEvent(event JobEvent) {
 
 	case StageFinish:
 		msg = "job finished"
+
+	case StageRemove:
+		msg = "job removed"
+
+	case StageQueue:
+		msg = "job queued"
+
+		if event.Error != nil {
+			attrs = append(attrs, slog.Any("reason", event.Error))
+		}
+
+	case StagePanic:
+		msg = "job panicked"
+
+	case StageRefresh:
+		msg = "job lock refreshed"
+
+	case StageLimit:
+		msg = "job hit concurrency limit"
+
+	case StageSkip:
+		msg = "job skipped"
+		attrs = append(attrs, slog.Any("reason", event.Error))
 	}
 
-	s.log.LogAttrs(context.Background(), s.eventLeveler.Level(), msg,
+	s.log.LogAttrs(ctx, s.eventLeveler.Level(), msg, attrs...)
+}
+
+// attrs returns attributes common for all records of the event
+func (s *SlogHandler) attrs(event JobEvent) []slog.Attr {
+	attrs := []slog.Attr{
 		slog.String("spec", event.JobSpec),
-		slog.String("name", event.JobName))
+		slog.String("name", event.JobName),
+	}
+
+	if event.RunID != "" {
+		attrs = append(attrs, slog.String("runId", event.RunID))
+	}
+
+	return attrs
 }
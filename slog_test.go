@@ -195,6 +195,193 @@ func TestSlogHandlerHandle(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "logs event for remove stage",
+			event: JobEvent{
+				JobSpec: "0 0 * * *",
+				JobName: "daily",
+				Stage:   StageRemove,
+			},
+			levelers: levelers{
+				event: slog.LevelInfo,
+			},
+			expected: []slogRecord{
+				{
+					level: slog.LevelInfo,
+					msg:   "job removed",
+					attrs: map[string]any{
+						"spec": "0 0 * * *",
+						"name": "daily",
+					},
+				},
+			},
+		},
+		{
+			name: "logs skip as event",
+			event: JobEvent{
+				JobSpec: "0 0 * * *",
+				JobName: "daily",
+				Stage:   StageSkip,
+				Error:   ErrJobPaused,
+			},
+			levelers: levelers{
+				error: slog.LevelError,
+				event: slog.LevelInfo,
+			},
+			expected: []slogRecord{
+				{
+					level: slog.LevelInfo,
+					msg:   "job skipped",
+					attrs: map[string]any{
+						"spec":   "0 0 * * *",
+						"name":   "daily",
+						"reason": ErrJobPaused,
+					},
+				},
+			},
+		},
+		{
+			name: "logs panic with stack",
+			event: JobEvent{
+				JobSpec: "@every 1s",
+				JobName: "cleanup",
+				Stage:   StagePanic,
+				Error:   &PanicError{Value: "boom", Stack: []byte("stack")},
+				Attempt: 1,
+			},
+			levelers: levelers{
+				error: slog.LevelError,
+			},
+			expected: []slogRecord{
+				{
+					level: slog.LevelError,
+					msg:   "job panicked",
+					attrs: map[string]any{
+						"spec":  "@every 1s",
+						"name":  "cleanup",
+						"error": &PanicError{Value: "boom", Stack: []byte("stack")},
+						"stack": "stack",
+					},
+				},
+			},
+		},
+		{
+			name: "logs error for refresh stage",
+			event: JobEvent{
+				JobSpec: "@every 1s",
+				JobName: "cleanup",
+				Stage:   StageRefresh,
+				Error:   assert.AnError,
+			},
+			levelers: levelers{
+				error: slog.LevelError,
+			},
+			expected: []slogRecord{
+				{
+					level: slog.LevelError,
+					msg:   "can't refresh job lock",
+					attrs: map[string]any{
+						"spec":  "@every 1s",
+						"name":  "cleanup",
+						"error": assert.AnError,
+					},
+				},
+			},
+		},
+		{
+			name: "logs error for limit stage",
+			event: JobEvent{
+				JobSpec: "@every 1s",
+				JobName: "cleanup",
+				Stage:   StageLimit,
+				Error:   ErrLimitReached,
+			},
+			levelers: levelers{
+				error: slog.LevelError,
+			},
+			expected: []slogRecord{
+				{
+					level: slog.LevelError,
+					msg:   "job hit concurrency limit",
+					attrs: map[string]any{
+						"spec":  "@every 1s",
+						"name":  "cleanup",
+						"error": ErrLimitReached,
+					},
+				},
+			},
+		},
+		{
+			name: "logs queue reason as event",
+			event: JobEvent{
+				JobSpec: "0 0 * * *",
+				JobName: "daily",
+				Stage:   StageQueue,
+				Error:   ErrLimitReached,
+			},
+			levelers: levelers{
+				error: slog.LevelError,
+				event: slog.LevelInfo,
+			},
+			expected: []slogRecord{
+				{
+					level: slog.LevelInfo,
+					msg:   "job queued",
+					attrs: map[string]any{
+						"spec":   "0 0 * * *",
+						"name":   "daily",
+						"reason": ErrLimitReached,
+					},
+				},
+			},
+		},
+		{
+			name: "logs run id",
+			event: JobEvent{
+				JobSpec: "0 0 * * *",
+				JobName: "daily",
+				Stage:   StageExec,
+				RunID:   "run",
+			},
+			levelers: levelers{
+				event: slog.LevelInfo,
+			},
+			expected: []slogRecord{
+				{
+					level: slog.LevelInfo,
+					msg:   "job executed",
+					attrs: map[string]any{
+						"spec":  "0 0 * * *",
+						"name":  "daily",
+						"runId": "run",
+					},
+				},
+			},
+		},
+		{
+			name: "logs lock not acquired as event",
+			event: JobEvent{
+				JobSpec: "0 0 * * *",
+				JobName: "daily",
+				Stage:   StageSkip,
+				Error:   ErrLockNotAcquired,
+			},
+			levelers: levelers{
+				error: slog.LevelError,
+				event: slog.LevelDebug,
+			},
+			expected: []slogRecord{
+				{
+					level: slog.LevelDebug,
+					msg:   "job skipped",
+					attrs: map[string]any{
+						"spec":   "0 0 * * *",
+						"name":   "daily",
+						"reason": ErrLockNotAcquired,
+					},
+				},
+			},
+		},
 		{
 			name: "skips logging when error level is nil",
 			event: JobEvent{
@@ -235,3 +422,33 @@ func TestSlogHandlerHandle(t *testing.T) {
 		})
 	}
 }
+
+type slogContextHandler struct {
+	slogCaptureHandler
+
+	values []any
+}
+
+type slogCtxKey struct{}
+
+func (h *slogContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.values = append(h.values, ctx.Value(slogCtxKey{}))
+	return h.slogCaptureHandler.Handle(ctx, r)
+}
+
+func TestSlogHandlerHandleContext(t *testing.T) {
+	t.Parallel()
+
+	capture := &slogContextHandler{}
+	handler := NewSlogHandler(slog.New(capture)).
+		WithError(slog.LevelError).
+		WithEvent(slog.LevelInfo)
+
+	ctx := context.WithValue(t.Context(), slogCtxKey{}, "value")
+
+	handler.HandleContext(ctx, JobEvent{Stage: StageExec})
+	handler.HandleContext(ctx, JobEvent{Stage: StageExec, Error: assert.AnError})
+	handler.Handle(JobEvent{Stage: StageExec})
+
+	assert.Equal(t, []any{"value", "value", nil}, capture.values)
+}
@@ -0,0 +1,139 @@
+package sqllock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/anticrew/gocron"
+)
+
+var _ gocron.Lock = (*Advisory)(nil)
+
+// advisory is an advisory lock acquired by a run
+type advisory struct {
+	name string
+	key  int64
+	conn *sql.Conn
+}
+
+// Advisory is a gocron.Lock based on PostgreSQL session-level advisory locks.
+// Each run holds a dedicated connection while locked; the lock is released if the connection breaks.
+// Advisory locks don't provide fencing tokens, use Lease if the command needs them
+type Advisory struct {
+	db   *sql.DB
+	name string
+
+	mu   sync.Mutex
+	runs map[runLock]advisory
+}
+
+// NewAdvisory creates an advisory lock
+func NewAdvisory(db *sql.DB) *Advisory {
+	return &Advisory{
+		db:   db,
+		runs: make(map[runLock]advisory),
+	}
+}
+
+// WithName sets the lock name used instead of the job name
+func (a *Advisory) WithName(name string) *Advisory {
+	a.name = name
+	return a
+}
+
+// Key returns the advisory lock key of the given name
+func (a *Advisory) Key(name string) int64 {
+	if a.name != "" {
+		name = a.name
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+
+	return int64(h.Sum64()) //nolint:gosec // advisory lock keys are signed, overflow is fine
+}
+
+// Lock tries to acquire the advisory lock of the job running in ctx without blocking.
+// It returns gocron.ErrLockNotAcquired if the lock is held by another session
+func (a *Advisory) Lock(ctx context.Context) error {
+	run, err := lockOf(ctx, a.name)
+	if err != nil {
+		return err
+	}
+
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("sql.Conn: %w", err)
+	}
+
+	adv := advisory{
+		name: run.name,
+		key:  a.Key(run.name),
+		conn: conn,
+	}
+
+	var locked bool
+	if err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, adv.key).Scan(&locked); err != nil {
+		// the lock may be acquired even if the query failed, e.g. on cancellation
+		discard(conn)
+		return fmt.Errorf("sql.QueryRowContext: %w", err)
+	}
+
+	if !locked {
+		return errors.Join(notAcquired(run.name), conn.Close())
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.runs[run] = adv
+
+	return nil
+}
+
+// Unlock releases the advisory lock held by the run in ctx and its connection.
+// The cancellation of ctx is ignored, but its deadline is kept. If the lock can't be released,
+// the connection is closed instead of being returned to the pool, so the session drops the lock
+func (a *Advisory) Unlock(ctx context.Context) error {
+	run, err := lockOf(ctx, a.name)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	adv, ok := a.runs[run]
+	delete(a.runs, run)
+	a.mu.Unlock()
+
+	if !ok {
+		return gocron.ErrLockNotHeld
+	}
+
+	ctx, cancel := detach(ctx)
+	defer cancel()
+
+	var unlocked bool
+	if err := adv.conn.QueryRowContext(ctx, `SELECT pg_advisory_unlock($1)`, adv.key).Scan(&unlocked); err != nil {
+		discard(adv.conn)
+		return fmt.Errorf("sql.QueryRowContext: %w", err)
+	}
+
+	if !unlocked {
+		discard(adv.conn)
+		return notHeld(adv.name)
+	}
+
+	return adv.conn.Close()
+}
+
+// discard closes the underlying connection instead of returning it to the pool,
+// so the session releases all its advisory locks
+func discard(conn *sql.Conn) {
+	// database/sql closes connections failing with driver.ErrBadConn
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+}
@@ -0,0 +1,123 @@
+package sqllock
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/anticrew/gocron"
+	"github.com/anticrew/gocron/gocrontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"modernc.org/sqlite"
+)
+
+var (
+	// advisoryLocks emulates PostgreSQL advisory lock functions in SQLite; locks aren't bound to sessions
+	advisoryLocks sync.Map
+	// failingUnlocks holds keys which pg_advisory_unlock fails to release
+	failingUnlocks sync.Map
+)
+
+func init() {
+	sqlite.MustRegisterScalarFunction("pg_try_advisory_lock", 1, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		_, loaded := advisoryLocks.LoadOrStore(args[0], struct{}{})
+		return !loaded, nil
+	})
+
+	sqlite.MustRegisterScalarFunction("pg_advisory_unlock", 1, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		if _, ok := failingUnlocks.Load(args[0]); ok {
+			return nil, errors.New("connection reset")
+		}
+
+		_, loaded := advisoryLocks.LoadAndDelete(args[0])
+		return loaded, nil
+	})
+}
+
+func TestAdvisory(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = openDB(t)
+		first   = NewAdvisory(db)
+		second  = NewAdvisory(db)
+		ctx     = gocrontest.RunContext(t.Context(), "advisory report", "run")
+		cleanup = gocrontest.RunContext(t.Context(), "advisory cleanup", "run")
+	)
+
+	require.NoError(t, first.Lock(ctx))
+	require.ErrorIs(t, second.Lock(ctx), gocron.ErrLockNotAcquired)
+	require.ErrorIs(t, second.Unlock(ctx), gocron.ErrLockNotHeld)
+
+	require.NoError(t, first.Lock(cleanup), "other jobs are not locked")
+	require.NoError(t, first.Unlock(cleanup), "locks of jobs with the same run ID are held separately")
+	require.ErrorIs(t, second.Lock(ctx), gocron.ErrLockNotAcquired)
+
+	require.NoError(t, first.Unlock(ctx))
+	require.NoError(t, second.Lock(ctx))
+	require.NoError(t, second.Unlock(ctx))
+
+	require.ErrorIs(t, first.Lock(t.Context()), gocron.ErrNoLockName)
+}
+
+func TestAdvisory_Key(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = openDB(t)
+		lock   = NewAdvisory(db)
+		shared = NewAdvisory(db).WithName("shared")
+	)
+
+	assert.Equal(t, lock.Key("report"), NewAdvisory(db).Key("report"))
+	assert.NotEqual(t, lock.Key("report"), lock.Key("cleanup"))
+	assert.Equal(t, lock.Key("shared"), shared.Key("report"))
+}
+
+func TestAdvisory_UnlockCancelled(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db   = openDB(t)
+		lock = NewAdvisory(db)
+		ctx  = gocrontest.RunContext(t.Context(), "advisory cancelled", "run")
+	)
+
+	require.NoError(t, lock.Lock(ctx))
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	require.NoError(t, lock.Unlock(cancelled))
+
+	_, locked := advisoryLocks.Load(lock.Key("advisory cancelled"))
+	assert.False(t, locked)
+	assert.Equal(t, 1, db.Stats().Idle, "the connection is returned to the pool")
+}
+
+func TestAdvisory_UnlockFailed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db   = openDB(t)
+		lock = NewAdvisory(db)
+		ctx  = gocrontest.RunContext(t.Context(), "advisory failed", "run")
+	)
+
+	require.NoError(t, lock.Lock(ctx))
+
+	failingUnlocks.Store(lock.Key("advisory failed"), struct{}{})
+	t.Cleanup(func() {
+		failingUnlocks.Delete(lock.Key("advisory failed"))
+		advisoryLocks.Delete(lock.Key("advisory failed"))
+	})
+
+	require.Error(t, lock.Unlock(ctx))
+
+	stats := db.Stats()
+	assert.Zero(t, stats.OpenConnections, "the connection holding the lock is closed")
+	assert.Zero(t, stats.Idle)
+}
@@ -0,0 +1,278 @@
+package sqllock
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/anticrew/gocron"
+)
+
+// DefaultTable is the default name of the lease table
+const DefaultTable = "gocron_locks"
+
+var (
+	_ gocron.RenewableLock = (*Lease)(nil)
+	_ gocron.ContextLock   = (*Lease)(nil)
+)
+
+// lease is a lease acquired by a run
+type lease struct {
+	name  string
+	owner string
+	token int64
+}
+
+// Lease is a gocron.RenewableLock keeping leases in a table with portable SQL.
+// Each lease row holds the owner run, the expiration time and the fencing token passed to the command context.
+// Expiration uses the local clocks of replicas, so ttl should be much longer than the clock skew
+type Lease struct {
+	db           *sql.DB
+	ttl          time.Duration
+	refresh      time.Duration
+	table        string
+	placeholders Placeholders
+	name         string
+
+	mu   sync.Mutex
+	runs map[runLock]lease
+}
+
+// NewLease creates a lease lock with the given lease duration. The lease is refreshed every third of ttl by default
+func NewLease(db *sql.DB, ttl time.Duration) *Lease {
+	return &Lease{
+		db:           db,
+		ttl:          ttl,
+		refresh:      ttl / 3,
+		table:        DefaultTable,
+		placeholders: Question,
+		runs:         make(map[runLock]lease),
+	}
+}
+
+// WithTable sets the name of the lease table; DefaultTable is used by default
+func (l *Lease) WithTable(table string) *Lease {
+	l.table = table
+	return l
+}
+
+// WithPlaceholders sets the query parameter syntax; Question is used by default
+func (l *Lease) WithPlaceholders(p Placeholders) *Lease {
+	l.placeholders = p
+	return l
+}
+
+// WithName sets the lease name used instead of the job name
+func (l *Lease) WithName(name string) *Lease {
+	l.name = name
+	return l
+}
+
+// WithRefreshInterval sets the interval of lease refreshing; non-positive value disables refreshing
+func (l *Lease) WithRefreshInterval(d time.Duration) *Lease {
+	l.refresh = d
+	return l
+}
+
+// Init creates the lease table if it doesn't exist
+func (l *Lease) Init(ctx context.Context) error {
+	_, err := l.db.ExecContext(ctx, l.query(`CREATE TABLE IF NOT EXISTS %s (
+	name VARCHAR(255) NOT NULL PRIMARY KEY,
+	owner VARCHAR(64) NOT NULL,
+	expires_at BIGINT NOT NULL,
+	token BIGINT NOT NULL
+)`))
+	if err != nil {
+		return fmt.Errorf("sql.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// Lock acquires the lease of the job running in ctx if it's free or expired.
+// It returns gocron.ErrLockNotAcquired if the lease is held by another run
+func (l *Lease) Lock(ctx context.Context) error {
+	run, err := lockOf(ctx, l.name)
+	if err != nil {
+		return err
+	}
+
+	var (
+		ls = lease{
+			name:  run.name,
+			owner: rand.Text(),
+		}
+		now       = time.Now()
+		expiresAt = now.Add(l.ttl).UnixMilli()
+	)
+
+	acquired, err := l.exec(ctx, `UPDATE %s SET owner = ?, expires_at = ?, token = token + 1 WHERE name = ? AND expires_at <= ?`,
+		ls.owner, expiresAt, ls.name, now.UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	if !acquired {
+		if err = l.insert(ctx, ls, expiresAt); err != nil {
+			return err
+		}
+	}
+
+	err = l.db.QueryRowContext(ctx, l.query(`SELECT token FROM %s WHERE name = ? AND owner = ?`), ls.name, ls.owner).
+		Scan(&ls.token)
+	if err != nil {
+		// the lease is already acquired, release it instead of keeping it until expiration
+		releaseCtx, cancel := detach(ctx)
+		defer cancel()
+
+		return errors.Join(fmt.Errorf("sql.QueryRowContext: %w", err), l.release(releaseCtx, ls))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.runs[run] = ls
+
+	return nil
+}
+
+// insert creates the lease row of a lock acquired for the first time
+func (l *Lease) insert(ctx context.Context, ls lease, expiresAt int64) error {
+	exists, err := l.exists(ctx, ls.name)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return notAcquired(ls.name)
+	}
+
+	_, err = l.db.ExecContext(ctx, l.query(`INSERT INTO %s (name, owner, expires_at, token) VALUES (?, ?, ?, 1)`),
+		ls.name, ls.owner, expiresAt)
+	if err == nil {
+		return nil
+	}
+
+	// the row may be inserted by another replica concurrently
+	if exists, _ = l.exists(ctx, ls.name); exists {
+		return notAcquired(ls.name)
+	}
+
+	return fmt.Errorf("sql.ExecContext: %w", err)
+}
+
+func (l *Lease) exists(ctx context.Context, name string) (bool, error) {
+	var count int
+	if err := l.db.QueryRowContext(ctx, l.query(`SELECT COUNT(*) FROM %s WHERE name = ?`), name).Scan(&count); err != nil {
+		return false, fmt.Errorf("sql.QueryRowContext: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// Unlock releases the lease if it's still held by the run in ctx.
+// The lease row is kept to keep fencing tokens increasing
+func (l *Lease) Unlock(ctx context.Context) error {
+	run, err := lockOf(ctx, l.name)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	ls, ok := l.runs[run]
+	delete(l.runs, run)
+	l.mu.Unlock()
+
+	if !ok {
+		return gocron.ErrLockNotHeld
+	}
+
+	return l.release(ctx, ls)
+}
+
+// release expires the lease if it's still held by its owner
+func (l *Lease) release(ctx context.Context, ls lease) error {
+	return l.update(ctx, ls, `UPDATE %s SET expires_at = 0 WHERE name = ? AND owner = ?`, ls.name, ls.owner)
+}
+
+// Refresh extends the lease held by the run in ctx unless it has expired
+func (l *Lease) Refresh(ctx context.Context) error {
+	run, err := lockOf(ctx, l.name)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	ls, ok := l.runs[run]
+	l.mu.Unlock()
+
+	if !ok {
+		return gocron.ErrLockNotHeld
+	}
+
+	now := time.Now()
+
+	return l.update(ctx, ls, `UPDATE %s SET expires_at = ? WHERE name = ? AND owner = ? AND expires_at > ?`,
+		now.Add(l.ttl).UnixMilli(), ls.name, ls.owner, now.UnixMilli())
+}
+
+// RefreshInterval returns the interval of lease refreshing
+func (l *Lease) RefreshInterval() time.Duration {
+	return l.refresh
+}
+
+// Context returns ctx with the fencing token of the lease held by the run in ctx
+func (l *Lease) Context(ctx context.Context) context.Context {
+	run, err := lockOf(ctx, l.name)
+	if err != nil {
+		return ctx
+	}
+
+	l.mu.Lock()
+	ls, ok := l.runs[run]
+	l.mu.Unlock()
+
+	if !ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, tokenKey{}, ls.token)
+}
+
+// update executes the query changing the lease row and returns gocron.ErrLockNotHeld if no rows were changed
+func (l *Lease) update(ctx context.Context, ls lease, query string, args ...any) error {
+	updated, err := l.exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		return notHeld(ls.name)
+	}
+
+	return nil
+}
+
+// exec executes the query and reports whether any rows were affected
+func (l *Lease) exec(ctx context.Context, query string, args ...any) (bool, error) {
+	res, err := l.db.ExecContext(ctx, l.query(query), args...)
+	if err != nil {
+		return false, fmt.Errorf("sql.ExecContext: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("sql.RowsAffected: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+// query formats the query for the lease table and placeholders
+func (l *Lease) query(query string) string {
+	return l.placeholders.rebind(fmt.Sprintf(query, l.table))
+}
@@ -0,0 +1,243 @@
+package sqllock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anticrew/gocron"
+	"github.com/anticrew/gocron/gocrontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"modernc.org/sqlite"
+)
+
+func newLease(t *testing.T, db *sql.DB) *Lease {
+	t.Helper()
+
+	lease := NewLease(db, time.Minute)
+	require.NoError(t, lease.Init(t.Context()))
+
+	return lease
+}
+
+// expire moves the expiration time of all leases to the past
+func expire(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.ExecContext(t.Context(), `UPDATE gocron_locks SET expires_at = 1`)
+	require.NoError(t, err)
+}
+
+// fencingToken returns the fencing token passed to the command of the run in ctx; zero if none
+func fencingToken(ctx context.Context, lock gocron.ContextLock) int64 {
+	token, _ := FencingToken(lock.Context(ctx))
+	return token
+}
+
+// failingConnector opens SQLite connections failing to prepare queries containing query
+type failingConnector struct {
+	path  string
+	query string
+}
+
+func (c failingConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.Driver().Open(c.path)
+	if err != nil {
+		return nil, err
+	}
+
+	return failingConn{Conn: conn, query: c.query}, nil
+}
+
+func (failingConnector) Driver() driver.Driver {
+	return &sqlite.Driver{}
+}
+
+// failingConn hides the optional interfaces of the SQLite connection, so all queries are prepared
+type failingConn struct {
+	driver.Conn
+	query string
+}
+
+func (c failingConn) Prepare(query string) (driver.Stmt, error) {
+	if strings.Contains(query, c.query) {
+		return nil, errors.New("connection reset")
+	}
+
+	return c.Conn.Prepare(query)
+}
+
+func TestLease_Replicas(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = openDB(t)
+		first   = newLease(t, db)
+		second  = newLease(t, db)
+		ctx     = gocrontest.RunContext(t.Context(), "report", "run")
+		cleanup = gocrontest.RunContext(t.Context(), "cleanup", "run")
+	)
+
+	require.NoError(t, first.Lock(ctx))
+	assert.Equal(t, int64(1), fencingToken(ctx, first))
+
+	require.ErrorIs(t, second.Lock(ctx), gocron.ErrLockNotAcquired)
+	require.ErrorIs(t, second.Unlock(ctx), gocron.ErrLockNotHeld)
+	require.NoError(t, second.Lock(cleanup), "other jobs are not locked")
+
+	require.NoError(t, first.Unlock(ctx))
+
+	require.NoError(t, second.Lock(ctx))
+	assert.Equal(t, int64(2), fencingToken(ctx, second))
+	assert.Equal(t, int64(1), fencingToken(cleanup, second), "leases of jobs with the same run ID are held separately")
+
+	require.NoError(t, second.Unlock(cleanup))
+	require.NoError(t, second.Unlock(ctx))
+}
+
+func TestLease_Expired(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = openDB(t)
+		first  = newLease(t, db)
+		second = newLease(t, db)
+		ctx    = gocrontest.RunContext(t.Context(), "report", "run")
+	)
+
+	require.NoError(t, first.Lock(ctx))
+	expire(t, db)
+
+	require.ErrorIs(t, first.Refresh(ctx), gocron.ErrLockNotHeld)
+
+	require.NoError(t, second.Lock(ctx))
+	assert.Equal(t, int64(2), fencingToken(ctx, second))
+
+	require.ErrorIs(t, first.Unlock(ctx), gocron.ErrLockNotHeld)
+	require.ErrorIs(t, first.Lock(ctx), gocron.ErrLockNotAcquired, "lease of another replica is kept")
+}
+
+func TestLease_LockFailed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path   = filepath.Join(t.TempDir(), "locks.db")
+		db     = sql.OpenDB(failingConnector{path: path, query: "SELECT token"})
+		first  = newLease(t, db)
+		second = newLease(t, openPath(t, path))
+		ctx    = gocrontest.RunContext(t.Context(), "report", "run")
+	)
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	err := first.Lock(ctx)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, gocron.ErrLockNotAcquired)
+
+	require.NoError(t, second.Lock(ctx), "the lease is released")
+	assert.Equal(t, int64(2), fencingToken(ctx, second))
+}
+
+func TestLease_Refresh(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db    = openDB(t)
+		lease = newLease(t, db)
+		ctx   = gocrontest.RunContext(t.Context(), "report", "run")
+	)
+
+	assert.Equal(t, 20*time.Second, lease.RefreshInterval())
+	require.ErrorIs(t, lease.Refresh(ctx), gocron.ErrLockNotHeld)
+
+	require.NoError(t, lease.Lock(ctx))
+
+	_, err := db.ExecContext(t.Context(), `UPDATE gocron_locks SET expires_at = ?`, time.Now().Add(time.Second).UnixMilli())
+	require.NoError(t, err)
+
+	require.NoError(t, lease.Refresh(ctx))
+
+	var expiresAt int64
+	require.NoError(t, db.QueryRowContext(t.Context(), `SELECT expires_at FROM gocron_locks`).Scan(&expiresAt))
+	assert.Greater(t, expiresAt, time.Now().Add(50*time.Second).UnixMilli())
+}
+
+func TestLease_Name(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		lease    func(db *sql.DB) *Lease
+		ctx      context.Context
+		expected string
+		err      error
+	}{
+		{
+			name:     "uses job name",
+			lease:    func(db *sql.DB) *Lease { return NewLease(db, time.Minute) },
+			ctx:      gocrontest.RunContext(t.Context(), "report", "run"),
+			expected: "report",
+		},
+		{
+			name:     "prefers lease name",
+			lease:    func(db *sql.DB) *Lease { return NewLease(db, time.Minute).WithName("shared") },
+			ctx:      gocrontest.RunContext(t.Context(), "report", "run"),
+			expected: "shared",
+		},
+		{
+			name:  "requires name",
+			lease: func(db *sql.DB) *Lease { return NewLease(db, time.Minute) },
+			ctx:   t.Context(),
+			err:   gocron.ErrNoLockName,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := openDB(t)
+			lease := tc.lease(db).WithTable("locks")
+			require.NoError(t, lease.Init(t.Context()))
+
+			err := lease.Lock(tc.ctx)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var name string
+			require.NoError(t, db.QueryRowContext(t.Context(), `SELECT name FROM locks`).Scan(&name))
+			assert.Equal(t, tc.expected, name)
+		})
+	}
+}
+
+func TestLease_Job(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = openDB(t)
+		lease  = newLease(t, db)
+		tokens []int64
+	)
+
+	job := gocron.NewCron(t.Context()).MustAdd("@every 1h", func(ctx context.Context) error {
+		token, _ := FencingToken(ctx)
+		tokens = append(tokens, token)
+
+		return nil
+	}).WithName("report").WithLock(lease)
+
+	require.NoError(t, job.RunNow(t.Context()))
+	require.NoError(t, job.RunNow(t.Context()))
+	assert.Equal(t, []int64{1, 2}, tokens)
+}
@@ -0,0 +1,93 @@
+// Package sqllock provides gocron locks on top of database/sql: a portable lease table
+// with fencing tokens and PostgreSQL advisory locks
+package sqllock
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anticrew/gocron"
+)
+
+// Placeholders identifies the query parameter syntax of the database driver
+type Placeholders int8
+
+const (
+	// Question uses ? placeholders, e.g. for SQLite and MySQL
+	Question Placeholders = iota + 1
+	// Dollar uses $1 placeholders, e.g. for PostgreSQL
+	Dollar
+)
+
+// rebind replaces ? placeholders of the query with the syntax of p
+func (p Placeholders) rebind(query string) string {
+	if p != Dollar {
+		return query
+	}
+
+	var (
+		b strings.Builder
+		n int
+	)
+
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+
+		n++
+		b.WriteString("$" + strconv.Itoa(n))
+	}
+
+	return b.String()
+}
+
+type tokenKey struct{}
+
+// FencingToken returns the fencing token of the lease held by the run of ctx.
+// Tokens increase every time the lease is acquired after expiration or release,
+// so storages can reject writes of a run that has lost its lease
+func FencingToken(ctx context.Context) (int64, bool) {
+	token, ok := ctx.Value(tokenKey{}).(int64)
+	return token, ok
+}
+
+// runLock identifies a lock held by a run, a run ID alone is not unique across jobs sharing the lock
+type runLock struct {
+	name  string
+	runID string
+}
+
+// lockOf returns the lock name of the job running in ctx, which is name if not empty, along with the run ID
+func lockOf(ctx context.Context, name string) (runLock, error) {
+	name, err := gocron.LockName(ctx, name)
+	if err != nil {
+		return runLock{}, err
+	}
+
+	info, _ := gocron.RunInfoFromContext(ctx)
+
+	return runLock{name: name, runID: info.RunID}, nil
+}
+
+func notAcquired(name string) error {
+	return fmt.Errorf("%w: %s", gocron.ErrLockNotAcquired, name)
+}
+
+func notHeld(name string) error {
+	return fmt.Errorf("%w: %s", gocron.ErrLockNotHeld, name)
+}
+
+// detach returns a copy of ctx which isn't cancelled with ctx but keeps its deadline,
+// e.g. to release a lock after the run is cancelled
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+
+	return detached, func() {}
+}
@@ -0,0 +1,68 @@
+package sqllock
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	return openPath(t, filepath.Join(t.TempDir(), "locks.db"))
+}
+
+func openPath(t *testing.T, path string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func TestPlaceholders_Rebind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		placeholders Placeholders
+		expected     string
+	}{
+		{
+			name:         "question",
+			placeholders: Question,
+			expected:     "UPDATE t SET a = ? WHERE b = ? AND c = ?",
+		},
+		{
+			name:         "dollar",
+			placeholders: Dollar,
+			expected:     "UPDATE t SET a = $1 WHERE b = $2 AND c = $3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.placeholders.rebind("UPDATE t SET a = ? WHERE b = ? AND c = ?"))
+		})
+	}
+}
+
+func TestFencingToken(t *testing.T) {
+	t.Parallel()
+
+	_, ok := FencingToken(t.Context())
+	assert.False(t, ok)
+
+	token, ok := FencingToken(context.WithValue(t.Context(), tokenKey{}, int64(3)))
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), token)
+}
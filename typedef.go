@@ -18,39 +18,262 @@ type Cron interface {
 	// Look at github.com/robfig/cron documentation for details about spec format
 	MustAdd(spec string, cmd Cmd) Job
 
-	// Start begins scheduling jobs.
-	// It should be called once, next calls without call Shutdown before will be ignored
-	Start()
+	// Remove unschedules the job. Running invocations are not interrupted and
+	// are still awaited by Shutdown
+	Remove(j Job) error
+
+	// RemoveByName unschedules all jobs with the given name.
+	// Running invocations are not interrupted and are still awaited by Shutdown
+	RemoveByName(name string) error
+
+	// Trigger runs all jobs with the given name immediately, one by one, outside their schedule.
+	// It returns the joined errors of the runs
+	Trigger(ctx context.Context, name string) error
+
+	// FindByName returns all registered jobs with the given name; nil if there are none
+	FindByName(name string) []Job
+
+	// Jobs returns a snapshot of all registered jobs ordered by the next run time
+	Jobs() []JobInfo
+
+	// Pause skips scheduled runs of all jobs until Resume is called.
+	// Jobs stay registered and can still be run manually
+	Pause()
+
+	// Resume continues scheduled runs paused by Pause.
+	// Jobs paused individually stay paused
+	Resume()
+
+	// State returns the current lifecycle state
+	State() State
+
+	// Leader reports whether the cron schedules jobs: it's running and, with WithElector, elected as the leader
+	Leader() bool
+
+	// Start begins scheduling jobs and moves the cron to StateRunning.
+	// It can be called in StateNew and StateStopped, otherwise ErrCronRunning or ErrCronStopping is returned
+	Start() error
 
 	// Shutdown stops scheduling and waits for running jobs to finish or context cancellation.
-	// It should be called once, next calls without call Start before will be ignored
+	// With WithCancelOnShutdown running jobs are cancelled once the context is done.
+	// The cron stays in StateStopping until all running jobs finish, even if the context is done earlier.
+	// It can be called in StateRunning only, otherwise ErrCronNotRunning is returned
 	Shutdown(ctx context.Context) error
 }
 
+// State is the cron lifecycle state.
+// The cron moves New → Running → Stopping → Stopped → Running and so on
+type State int8
+
+const (
+	// StateNew indicates the cron has never been started
+	StateNew State = iota
+	// StateRunning indicates the cron schedules jobs
+	StateRunning
+	// StateStopping indicates Shutdown was called and some jobs are still running
+	StateStopping
+	// StateStopped indicates the cron does not schedule jobs and no jobs are running
+	StateStopped
+)
+
+// String returns the state name
+func (s State) String() string {
+	switch s {
+	case StateNew:
+		return "new"
+
+	case StateRunning:
+		return "running"
+
+	case StateStopping:
+		return "stopping"
+
+	case StateStopped:
+		return "stopped"
+	}
+
+	return "unknown"
+}
+
 // Job configures a scheduled job.
-// Lock acquisition uses the parent context without timeout; callers should implement
-// lock timeouts in the Lock itself if needed.
+// Lock acquisition uses the run context limited by WithLockTimeout.
+// Unlock uses the run context values without its cancellation, so the lock is released after the run is cancelled
 type Job interface {
 	// WithName sets the human-readable name used in handlers
 	WithName(name string) Job
 	// WithTimeout sets the job timeout; non-positive value disables timeout
 	WithTimeout(t time.Duration) Job
-	// WithLock sets the lock used to guard concurrent runs
+	// WithLock sets the lock used to guard concurrent runs instead of the default lock provider.
+	// RenewableLock leases are refreshed while the command is running
 	WithLock(lock Lock) Job
+	// WithLockTimeout sets the timeout of Lock, Unlock and RenewableLock.Refresh calls; non-positive value disables timeout
+	WithLockTimeout(t time.Duration) Job
 	// WithHandler sets the error handler used by this job; nil disabled error handling
 	WithHandler(h Handler) Job
+	// WithGroup sets the group of the job limited by WithGroupLimit
+	WithGroup(group string) Job
+	// WithOverlapPolicy sets the behavior of a run started while a previous run is still running
+	WithOverlapPolicy(p OverlapPolicy) Job
+	// WithRetry sets the retry policy for failed command executions.
+	// Retries run within the same lock hold and job timeout
+	WithRetry(p RetryPolicy) Job
+
+	// Remove unschedules the job from the cron it was added to
+	Remove() error
+	// RunNow executes the job immediately outside its schedule and returns its error.
+	// The run uses the same lock, timeout and handler as scheduled runs and ignores pauses
+	RunNow(ctx context.Context) error
+	// Reschedule replaces the job schedule with the given spec, keeping its name, lock, handler and timeout.
+	// The spec is validated with the parser configured for the cron
+	Reschedule(spec string) error
+	// Pause skips scheduled runs of the job until Resume is called
+	Pause()
+	// Resume continues scheduled runs of the job paused by Pause
+	Resume()
+}
+
+// OverlapPolicy defines the behavior of a job run started while a previous run of the same job is still running.
+// Policies are applied in-process and don't require a Lock
+type OverlapPolicy int8
+
+const (
+	// OverlapAllowConcurrent runs invocations concurrently
+	OverlapAllowConcurrent OverlapPolicy = iota
+	// OverlapSkipIfRunning skips the run with StageSkip and ErrJobRunning reason
+	OverlapSkipIfRunning
+	// OverlapQueueOne delays the run until the previous one ends, reporting StageQueue.
+	// Runs started while another one is already queued are skipped with ErrJobRunning reason,
+	// a queued run is skipped with ErrCronStopping reason on shutdown
+	OverlapQueueOne
+	// OverlapCancelPrevious cancels the context of the previous run and delays the run until it returns,
+	// reporting StageQueue. Only the newest run waits: a waiting run is skipped with ErrJobRunning once a newer one starts
+	// and with ErrCronStopping on shutdown
+	OverlapCancelPrevious
+)
+
+// LimitPolicy defines the behavior of a job run started while a concurrency limit is reached,
+// see WithMaxConcurrentRuns and WithGroupLimit
+type LimitPolicy int8
+
+const (
+	// LimitWait delays the run until a running job ends, reporting StageQueue with ErrLimitReached reason.
+	// Waiting runs are skipped with StageSkip and ErrCronStopping reason once Shutdown is called
+	LimitWait LimitPolicy = iota
+	// LimitSkip skips the run with StageSkip and ErrLimitReached reason
+	LimitSkip
+	// LimitFail fails the run with StageLimit and ErrLimitReached error
+	LimitFail
+)
+
+// RetryPolicy configures retries of failed command executions
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one; values below 2 disable retries
+	MaxAttempts int
+	// Backoff returns the delay before the retry attempt; nil retries without delay
+	Backoff Backoff
+	// Jitter randomizes delays by up to the given fraction of the delay in both directions, from 0 to 1
+	Jitter float64
+	// Retryable reports whether the error should be retried; nil retries all errors
+	Retryable func(err error) bool
+}
+
+// Backoff returns the delay before the attempt; the first retry is attempt 2
+type Backoff func(attempt int) time.Duration
+
+// JobInfo is a snapshot of a registered job state
+type JobInfo struct {
+	// Name is the human-readable job name
+	Name string
+	// Spec is the cron spec the job is scheduled with
+	Spec string
+	// Group is the group set by Job.WithGroup
+	Group string
+	// Next is the next scheduled run time; zero if the cron is not running
+	Next time.Time
+	// Prev is the last scheduled run time; zero if the job has not been run yet
+	Prev time.Time
+	// Paused reports whether the job is paused by Job.Pause
+	Paused bool
+	// Running is the number of currently running invocations
+	Running int
+	// LastError is the error of the last finished invocation
+	LastError error
+	// LastDuration is the duration of the last finished invocation
+	LastDuration time.Duration
+}
+
+// RenewableLock is a Lock with a lease that expires unless refreshed, e.g. a TTL-based distributed lock.
+// Jobs refresh the lease every RefreshInterval while the command is running and cancel the command context
+// with ErrLockLost if refresh fails, so the command doesn't run without the lock
+type RenewableLock interface {
+	Lock
+	// Refresh extends the lease of the acquired lock
+	Refresh(ctx context.Context) error
+	// RefreshInterval returns the interval between Refresh calls; non-positive value disables refreshing
+	RefreshInterval() time.Duration
+}
+
+// Tracer wraps job runs in spans, see WithTracer. Look at the otel subpackage for OpenTelemetry tracing.
+// Contexts passed to the methods hold RunInfo; returned contexts are passed down to Lock, Cmd and handlers
+type Tracer interface {
+	// StartRun starts the span of a run and returns a function ending it with the run error
+	StartRun(ctx context.Context) (context.Context, func(err error))
+	// StartStage starts the span of a run step and returns a function ending it with the step error:
+	// StageStart for lock acquisition, StageExec for command execution attempts,
+	// StageRefresh for lease refresh and StageFinish for unlock
+	StartStage(ctx context.Context, stage Stage) (context.Context, func(err error))
+}
+
+// Clock is the source of time used to schedule jobs, see WithClock
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine once d elapses
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a call scheduled by Clock.AfterFunc; *time.Timer implements it
+type Timer interface {
+	// Stop prevents the call; it returns false if the call has already been started or stopped
+	Stop() bool
+}
+
+// Elector elects the leader among cron replicas, see WithElector
+type Elector interface {
+	// Elect blocks until the replica becomes the leader or ctx is done.
+	// The returned context is done once the leadership is lost
+	Elect(ctx context.Context) (context.Context, error)
+	// Resign gives up the leadership acquired by Elect
+	Resign(ctx context.Context) error
+}
+
+// LockProvider returns the lock of the job with the given name; nil means the job runs without a lock
+type LockProvider func(jobName string) Lock
+
+// ContextLock is a Lock passing lock details, e.g. a fencing token, to the command
+type ContextLock interface {
+	Lock
+	// Context returns the command context of the run holding the lock; it's called after successful Lock
+	Context(ctx context.Context) context.Context
+}
+
+// RunRef identifies a job invocation
+type RunRef struct {
+	JobName string
+	RunID   string
 }
 
 // Lock guards concurrent job runs
 type Lock interface {
-	// Lock acquires the lock
+	// Lock acquires the lock. It should return ErrLockNotAcquired, possibly wrapped,
+	// if the lock is held elsewhere, e.g. by another replica
 	Lock(ctx context.Context) error
 	// Unlock releases the lock
 	Unlock(ctx context.Context) error
 }
 
 // Stage identifies the job lifecycle step for handler callbacks
-type Stage int8
+type Stage int16
 
 const (
 	// StageStart indicates lock and start stage
@@ -59,13 +282,118 @@ const (
 	StageExec
 	// StageFinish indicates unlock and finish stage
 	StageFinish
+	// StageRemove indicates the job was removed from the cron
+	StageRemove
+	// StageSkip indicates a run was skipped; the event error holds the reason
+	StageSkip
+	// StageQueue indicates a run waits for the previous run to end
+	StageQueue
+	// StagePanic indicates the job command panicked; the event error is *PanicError
+	StagePanic
+	// StageRefresh indicates the lease of RenewableLock was refreshed
+	StageRefresh
+	// StageLimit indicates a run failed because of a concurrency limit with LimitFail policy;
+	// the event error wraps ErrLimitReached
+	StageLimit
 )
 
+// String returns the stage name
+func (s Stage) String() string {
+	switch s {
+	case StageStart:
+		return "start"
+
+	case StageExec:
+		return "exec"
+
+	case StageFinish:
+		return "finish"
+
+	case StageRemove:
+		return "remove"
+
+	case StageSkip:
+		return "skip"
+
+	case StageQueue:
+		return "queue"
+
+	case StagePanic:
+		return "panic"
+
+	case StageRefresh:
+		return "refresh"
+
+	case StageLimit:
+		return "limit"
+	}
+
+	return "unknown"
+}
+
+// Trigger identifies what started a job run
+type Trigger int8
+
+const (
+	// TriggerSchedule indicates a run started by the schedule
+	TriggerSchedule Trigger = iota + 1
+	// TriggerManual indicates a run started by Job.RunNow or Cron.Trigger
+	TriggerManual
+	// TriggerRetry indicates a retry attempt of a failed command execution
+	TriggerRetry
+)
+
+// String returns the trigger name
+func (t Trigger) String() string {
+	switch t {
+	case TriggerSchedule:
+		return "schedule"
+
+	case TriggerManual:
+		return "manual"
+
+	case TriggerRetry:
+		return "retry"
+	}
+
+	return "unknown"
+}
+
+// JobEvent describes a job lifecycle step.
+// Run details are zero for events not related to a particular run, e.g. StageRemove
 type JobEvent struct {
 	JobSpec string
 	JobName string
 	Stage   Stage
 	Error   error
+
+	// RunID uniquely identifies the run the event belongs to
+	RunID string
+	// ScheduledAt is the time the run was planned at; equals StartedAt for manual runs
+	ScheduledAt time.Time
+	// StartedAt is the time the run actually started at
+	StartedAt time.Time
+	// Duration is the duration of the stage; set for StageStart, StageExec, StagePanic, StageRefresh and StageFinish
+	Duration time.Duration
+	// Attempt is the command execution attempt starting from 1; set for StageExec and StagePanic only
+	Attempt int
+	// Trigger is the source of the run; TriggerRetry for attempts after the first one
+	Trigger Trigger
+}
+
+// RunInfo describes a job run. It is available in contexts passed to Lock, Cmd and ContextHandler
+// via RunInfoFromContext
+type RunInfo struct {
+	JobSpec string
+	JobName string
+	// RunID uniquely identifies the run
+	RunID string
+	// ScheduledAt is the time the run was planned at; equals StartedAt for manual runs
+	ScheduledAt time.Time
+	// StartedAt is the time the run actually started at
+	StartedAt time.Time
+	// Trigger is the source of the run
+	Trigger Trigger
 }
 
 // Handler receives job events and errors
@@ -73,6 +401,13 @@ type Handler interface {
 	Handle(event JobEvent)
 }
 
+// ContextHandler is a Handler that receives the run context, e.g. to keep trace IDs and request-scoped values.
+// Jobs call HandleContext instead of Handle for handlers implementing it
+type ContextHandler interface {
+	Handler
+	HandleContext(ctx context.Context, event JobEvent)
+}
+
 // HandlerFunc adapts a function to a Handler
 type HandlerFunc func(event JobEvent)
 
@@ -80,3 +415,67 @@ type HandlerFunc func(event JobEvent)
 func (f HandlerFunc) Handle(event JobEvent) {
 	f(event)
 }
+
+// ContextHandlerFunc adapts a function to a ContextHandler
+type ContextHandlerFunc func(ctx context.Context, event JobEvent)
+
+// Handle calls the wrapped function with context.Background
+func (f ContextHandlerFunc) Handle(event JobEvent) {
+	f(context.Background(), event)
+}
+
+// HandleContext calls the wrapped function
+func (f ContextHandlerFunc) HandleContext(ctx context.Context, event JobEvent) {
+	f(ctx, event)
+}
+
+// AdaptHandler returns h as a ContextHandler. Handlers implementing ContextHandler are returned as is,
+// others ignore the context. Nil handler is returned as nil
+func AdaptHandler(h Handler) ContextHandler {
+	switch h := h.(type) {
+	case nil:
+		return nil
+
+	case ContextHandler:
+		return h
+
+	default:
+		return handlerAdapter{h}
+	}
+}
+
+type handlerAdapter struct {
+	Handler
+}
+
+// HandleContext calls Handle ignoring the context
+func (a handlerAdapter) HandleContext(_ context.Context, event JobEvent) {
+	a.Handle(event)
+}
+
+// MultiHandler returns a ContextHandler passing every event to all handlers in order, e.g. to log and count events.
+// Nil handlers are ignored
+func MultiHandler(handlers ...Handler) ContextHandler {
+	multi := make(multiHandler, 0, len(handlers))
+	for _, h := range handlers {
+		if h != nil {
+			multi = append(multi, AdaptHandler(h))
+		}
+	}
+
+	return multi
+}
+
+type multiHandler []ContextHandler
+
+// Handle passes the event to all handlers with context.Background
+func (m multiHandler) Handle(event JobEvent) {
+	m.HandleContext(context.Background(), event)
+}
+
+// HandleContext passes the event to all handlers
+func (m multiHandler) HandleContext(ctx context.Context, event JobEvent) {
+	for _, h := range m {
+		h.HandleContext(ctx, event)
+	}
+}
@@ -1,6 +1,8 @@
 package gocron
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +50,147 @@ func TestHandlerFuncHandle(t *testing.T) {
 		})
 	}
 }
+
+func TestStateString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		state    State
+		expected string
+	}{
+		{state: StateNew, expected: "new"},
+		{state: StateRunning, expected: "running"},
+		{state: StateStopping, expected: "stopping"},
+		{state: StateStopped, expected: "stopped"},
+		{state: State(-1), expected: "unknown"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.state.String())
+		})
+	}
+}
+
+func TestTriggerString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		trigger  Trigger
+		expected string
+	}{
+		{trigger: TriggerSchedule, expected: "schedule"},
+		{trigger: TriggerManual, expected: "manual"},
+		{trigger: TriggerRetry, expected: "retry"},
+		{trigger: 0, expected: "unknown"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.trigger.String())
+		})
+	}
+}
+
+func TestStageString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		stage    Stage
+		expected string
+	}{
+		{stage: StageStart, expected: "start"},
+		{stage: StageExec, expected: "exec"},
+		{stage: StageFinish, expected: "finish"},
+		{stage: StageRemove, expected: "remove"},
+		{stage: StageSkip, expected: "skip"},
+		{stage: StageQueue, expected: "queue"},
+		{stage: StagePanic, expected: "panic"},
+		{stage: StageRefresh, expected: "refresh"},
+		{stage: StageLimit, expected: "limit"},
+		{stage: StageExec | StagePanic, expected: "unknown"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.stage.String())
+		})
+	}
+}
+
+func TestAdaptHandler(t *testing.T) {
+	t.Parallel()
+
+	type ctxKey struct{}
+
+	ctx := context.WithValue(t.Context(), ctxKey{}, "value")
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, AdaptHandler(nil))
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		t.Parallel()
+
+		var got JobEvent
+		h := AdaptHandler(HandlerFunc(func(event JobEvent) {
+			got = event
+		}))
+
+		h.HandleContext(ctx, JobEvent{JobName: "name"})
+		assert.Equal(t, JobEvent{JobName: "name"}, got)
+	})
+
+	t.Run("context handler", func(t *testing.T) {
+		t.Parallel()
+
+		var values []any
+		h := AdaptHandler(ContextHandlerFunc(func(ctx context.Context, _ JobEvent) {
+			values = append(values, ctx.Value(ctxKey{}))
+		}))
+
+		h.HandleContext(ctx, JobEvent{})
+		h.Handle(JobEvent{})
+
+		assert.Equal(t, []any{"value", nil}, values)
+	})
+}
+
+func TestMultiHandler(t *testing.T) {
+	t.Parallel()
+
+	type ctxKey struct{}
+
+	var (
+		ctx    = context.WithValue(t.Context(), ctxKey{}, "value")
+		events []string
+	)
+
+	h := MultiHandler(
+		HandlerFunc(func(event JobEvent) {
+			events = append(events, "first "+event.JobName)
+		}),
+		nil,
+		ContextHandlerFunc(func(ctx context.Context, event JobEvent) {
+			events = append(events, fmt.Sprint("second ", event.JobName, " ", ctx.Value(ctxKey{})))
+		}),
+	)
+
+	h.HandleContext(ctx, JobEvent{JobName: "name"})
+	h.Handle(JobEvent{JobName: "other"})
+
+	assert.Equal(t, []string{
+		"first name",
+		"second name value",
+		"first other",
+		"second other <nil>",
+	}, events)
+}